@@ -160,6 +160,175 @@ func (x *ResultType) GetResult() bool {
 	return false
 }
 
+// UsageRecord adds the tokens of a generation job to its merge and to the
+// monthly totals of the repository
+type UsageRecord struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	MergeId string                 `protobuf:"bytes,1,opt,name=merge_id,json=mergeId,proto3" json:"merge_id,omitempty"`
+	// repository is owner/name
+	Repository    string        `protobuf:"bytes,2,opt,name=repository,proto3" json:"repository,omitempty"`
+	Usage         []*TokenUsage `protobuf:"bytes,3,rep,name=usage,proto3" json:"usage,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UsageRecord) Reset() {
+	*x = UsageRecord{}
+	mi := &file_database_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UsageRecord) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UsageRecord) ProtoMessage() {}
+
+func (x *UsageRecord) ProtoReflect() protoreflect.Message {
+	mi := &file_database_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UsageRecord.ProtoReflect.Descriptor instead.
+func (*UsageRecord) Descriptor() ([]byte, []int) {
+	return file_database_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *UsageRecord) GetMergeId() string {
+	if x != nil {
+		return x.MergeId
+	}
+	return ""
+}
+
+func (x *UsageRecord) GetRepository() string {
+	if x != nil {
+		return x.Repository
+	}
+	return ""
+}
+
+func (x *UsageRecord) GetUsage() []*TokenUsage {
+	if x != nil {
+		return x.Usage
+	}
+	return nil
+}
+
+type MonthlyUsageRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Repository string                 `protobuf:"bytes,1,opt,name=repository,proto3" json:"repository,omitempty"`
+	// month is YYYY-MM in UTC
+	Month         string `protobuf:"bytes,2,opt,name=month,proto3" json:"month,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MonthlyUsageRequest) Reset() {
+	*x = MonthlyUsageRequest{}
+	mi := &file_database_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MonthlyUsageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MonthlyUsageRequest) ProtoMessage() {}
+
+func (x *MonthlyUsageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_database_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MonthlyUsageRequest.ProtoReflect.Descriptor instead.
+func (*MonthlyUsageRequest) Descriptor() ([]byte, []int) {
+	return file_database_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *MonthlyUsageRequest) GetRepository() string {
+	if x != nil {
+		return x.Repository
+	}
+	return ""
+}
+
+func (x *MonthlyUsageRequest) GetMonth() string {
+	if x != nil {
+		return x.Month
+	}
+	return ""
+}
+
+type UsageReport struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Usage []*TokenUsage          `protobuf:"bytes,1,rep,name=usage,proto3" json:"usage,omitempty"`
+	// merges is the number of generation jobs counted in a monthly report
+	Merges        int64 `protobuf:"varint,2,opt,name=merges,proto3" json:"merges,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UsageReport) Reset() {
+	*x = UsageReport{}
+	mi := &file_database_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UsageReport) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UsageReport) ProtoMessage() {}
+
+func (x *UsageReport) ProtoReflect() protoreflect.Message {
+	mi := &file_database_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UsageReport.ProtoReflect.Descriptor instead.
+func (*UsageReport) Descriptor() ([]byte, []int) {
+	return file_database_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *UsageReport) GetUsage() []*TokenUsage {
+	if x != nil {
+		return x.Usage
+	}
+	return nil
+}
+
+func (x *UsageReport) GetMerges() int64 {
+	if x != nil {
+		return x.Merges
+	}
+	return 0
+}
+
 var File_database_proto protoreflect.FileDescriptor
 
 var file_database_proto_rawDesc = []byte{
@@ -176,35 +345,73 @@ var file_database_proto_rawDesc = []byte{
 	0x64, 0x2e, 0x43, 0x61, 0x63, 0x68, 0x65, 0x64, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x73,
 	0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x24, 0x0a, 0x0a, 0x52, 0x65, 0x73, 0x75, 0x6c,
 	0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x32, 0xff, 0x02,
-	0x0a, 0x0f, 0x44, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63,
-	0x65, 0x12, 0x57, 0x0a, 0x03, 0x53, 0x65, 0x74, 0x12, 0x26, 0x2e, 0x63, 0x6f, 0x64, 0x65, 0x73,
+	0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x22, 0x84, 0x01,
+	0x0a, 0x0b, 0x55, 0x73, 0x61, 0x67, 0x65, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x12, 0x19, 0x0a,
+	0x08, 0x6d, 0x65, 0x72, 0x67, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x07, 0x6d, 0x65, 0x72, 0x67, 0x65, 0x49, 0x64, 0x12, 0x1e, 0x0a, 0x0a, 0x72, 0x65, 0x70, 0x6f,
+	0x73, 0x69, 0x74, 0x6f, 0x72, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x72, 0x65,
+	0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x79, 0x12, 0x3a, 0x0a, 0x05, 0x75, 0x73, 0x61, 0x67,
+	0x65, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x63, 0x6f, 0x64, 0x65, 0x73, 0x6f,
+	0x75, 0x72, 0x63, 0x65, 0x72, 0x65, 0x72, 0x5f, 0x62, 0x6f, 0x74, 0x2e, 0x73, 0x68, 0x61, 0x72,
+	0x65, 0x64, 0x2e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x55, 0x73, 0x61, 0x67, 0x65, 0x52, 0x05, 0x75,
+	0x73, 0x61, 0x67, 0x65, 0x22, 0x4b, 0x0a, 0x13, 0x4d, 0x6f, 0x6e, 0x74, 0x68, 0x6c, 0x79, 0x55,
+	0x73, 0x61, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x72,
+	0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0a, 0x72, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x6d,
+	0x6f, 0x6e, 0x74, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6d, 0x6f, 0x6e, 0x74,
+	0x68, 0x22, 0x61, 0x0a, 0x0b, 0x55, 0x73, 0x61, 0x67, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74,
+	0x12, 0x3a, 0x0a, 0x05, 0x75, 0x73, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x24, 0x2e, 0x63, 0x6f, 0x64, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x72, 0x65, 0x72, 0x5f,
+	0x62, 0x6f, 0x74, 0x2e, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x2e, 0x54, 0x6f, 0x6b, 0x65, 0x6e,
+	0x55, 0x73, 0x61, 0x67, 0x65, 0x52, 0x05, 0x75, 0x73, 0x61, 0x67, 0x65, 0x12, 0x16, 0x0a, 0x06,
+	0x6d, 0x65, 0x72, 0x67, 0x65, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x6d, 0x65,
+	0x72, 0x67, 0x65, 0x73, 0x32, 0xb1, 0x05, 0x0a, 0x0f, 0x44, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73,
+	0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x57, 0x0a, 0x03, 0x53, 0x65, 0x74, 0x12,
+	0x26, 0x2e, 0x63, 0x6f, 0x64, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x72, 0x65, 0x72, 0x5f,
+	0x62, 0x6f, 0x74, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x2e, 0x4b, 0x65, 0x79,
+	0x56, 0x61, 0x6c, 0x54, 0x79, 0x70, 0x65, 0x1a, 0x26, 0x2e, 0x63, 0x6f, 0x64, 0x65, 0x73, 0x6f,
+	0x75, 0x72, 0x63, 0x65, 0x72, 0x65, 0x72, 0x5f, 0x62, 0x6f, 0x74, 0x2e, 0x64, 0x61, 0x74, 0x61,
+	0x62, 0x61, 0x73, 0x65, 0x2e, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x54, 0x79, 0x70, 0x65, 0x22,
+	0x00, 0x12, 0x56, 0x0a, 0x03, 0x47, 0x65, 0x74, 0x12, 0x23, 0x2e, 0x63, 0x6f, 0x64, 0x65, 0x73,
 	0x6f, 0x75, 0x72, 0x63, 0x65, 0x72, 0x65, 0x72, 0x5f, 0x62, 0x6f, 0x74, 0x2e, 0x64, 0x61, 0x74,
-	0x61, 0x62, 0x61, 0x73, 0x65, 0x2e, 0x4b, 0x65, 0x79, 0x56, 0x61, 0x6c, 0x54, 0x79, 0x70, 0x65,
-	0x1a, 0x26, 0x2e, 0x63, 0x6f, 0x64, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x72, 0x65, 0x72,
-	0x5f, 0x62, 0x6f, 0x74, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x2e, 0x52, 0x65,
-	0x73, 0x75, 0x6c, 0x74, 0x54, 0x79, 0x70, 0x65, 0x22, 0x00, 0x12, 0x56, 0x0a, 0x03, 0x47, 0x65,
-	0x74, 0x12, 0x23, 0x2e, 0x63, 0x6f, 0x64, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x72, 0x65,
-	0x72, 0x5f, 0x62, 0x6f, 0x74, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x2e, 0x4b,
-	0x65, 0x79, 0x54, 0x79, 0x70, 0x65, 0x1a, 0x28, 0x2e, 0x63, 0x6f, 0x64, 0x65, 0x73, 0x6f, 0x75,
-	0x72, 0x63, 0x65, 0x72, 0x65, 0x72, 0x5f, 0x62, 0x6f, 0x74, 0x2e, 0x73, 0x68, 0x61, 0x72, 0x65,
-	0x64, 0x2e, 0x43, 0x61, 0x63, 0x68, 0x65, 0x64, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x73,
-	0x22, 0x00, 0x12, 0x57, 0x0a, 0x06, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x12, 0x23, 0x2e, 0x63,
+	0x61, 0x62, 0x61, 0x73, 0x65, 0x2e, 0x4b, 0x65, 0x79, 0x54, 0x79, 0x70, 0x65, 0x1a, 0x28, 0x2e,
+	0x63, 0x6f, 0x64, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x72, 0x65, 0x72, 0x5f, 0x62, 0x6f,
+	0x74, 0x2e, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x2e, 0x43, 0x61, 0x63, 0x68, 0x65, 0x64, 0x43,
+	0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x73, 0x22, 0x00, 0x12, 0x57, 0x0a, 0x06, 0x44, 0x65, 0x6c,
+	0x65, 0x74, 0x65, 0x12, 0x23, 0x2e, 0x63, 0x6f, 0x64, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65,
+	0x72, 0x65, 0x72, 0x5f, 0x62, 0x6f, 0x74, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65,
+	0x2e, 0x4b, 0x65, 0x79, 0x54, 0x79, 0x70, 0x65, 0x1a, 0x26, 0x2e, 0x63, 0x6f, 0x64, 0x65, 0x73,
+	0x6f, 0x75, 0x72, 0x63, 0x65, 0x72, 0x65, 0x72, 0x5f, 0x62, 0x6f, 0x74, 0x2e, 0x64, 0x61, 0x74,
+	0x61, 0x62, 0x61, 0x73, 0x65, 0x2e, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x54, 0x79, 0x70, 0x65,
+	0x22, 0x00, 0x12, 0x62, 0x0a, 0x11, 0x49, 0x73, 0x52, 0x65, 0x74, 0x72, 0x69, 0x65, 0x73, 0x45,
+	0x78, 0x68, 0x61, 0x75, 0x74, 0x65, 0x64, 0x12, 0x23, 0x2e, 0x63, 0x6f, 0x64, 0x65, 0x73, 0x6f,
+	0x75, 0x72, 0x63, 0x65, 0x72, 0x65, 0x72, 0x5f, 0x62, 0x6f, 0x74, 0x2e, 0x64, 0x61, 0x74, 0x61,
+	0x62, 0x61, 0x73, 0x65, 0x2e, 0x4b, 0x65, 0x79, 0x54, 0x79, 0x70, 0x65, 0x1a, 0x26, 0x2e, 0x63,
 	0x6f, 0x64, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x72, 0x65, 0x72, 0x5f, 0x62, 0x6f, 0x74,
-	0x2e, 0x64, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x2e, 0x4b, 0x65, 0x79, 0x54, 0x79, 0x70,
-	0x65, 0x1a, 0x26, 0x2e, 0x63, 0x6f, 0x64, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x72, 0x65,
-	0x72, 0x5f, 0x62, 0x6f, 0x74, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x2e, 0x52,
-	0x65, 0x73, 0x75, 0x6c, 0x74, 0x54, 0x79, 0x70, 0x65, 0x22, 0x00, 0x12, 0x62, 0x0a, 0x11, 0x49,
-	0x73, 0x52, 0x65, 0x74, 0x72, 0x69, 0x65, 0x73, 0x45, 0x78, 0x68, 0x61, 0x75, 0x74, 0x65, 0x64,
-	0x12, 0x23, 0x2e, 0x63, 0x6f, 0x64, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x72, 0x65, 0x72,
-	0x5f, 0x62, 0x6f, 0x74, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x2e, 0x4b, 0x65,
-	0x79, 0x54, 0x79, 0x70, 0x65, 0x1a, 0x26, 0x2e, 0x63, 0x6f, 0x64, 0x65, 0x73, 0x6f, 0x75, 0x72,
+	0x2e, 0x64, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x2e, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74,
+	0x54, 0x79, 0x70, 0x65, 0x22, 0x00, 0x12, 0x60, 0x0a, 0x0b, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64,
+	0x55, 0x73, 0x61, 0x67, 0x65, 0x12, 0x27, 0x2e, 0x63, 0x6f, 0x64, 0x65, 0x73, 0x6f, 0x75, 0x72,
 	0x63, 0x65, 0x72, 0x65, 0x72, 0x5f, 0x62, 0x6f, 0x74, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x62, 0x61,
-	0x73, 0x65, 0x2e, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x54, 0x79, 0x70, 0x65, 0x22, 0x00, 0x42,
-	0x2e, 0x5a, 0x2c, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x63, 0x6f,
-	0x64, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x72, 0x65, 0x72, 0x2d, 0x62, 0x6f, 0x74, 0x2f,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x64, 0x62,
-	0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x73, 0x65, 0x2e, 0x55, 0x73, 0x61, 0x67, 0x65, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x1a, 0x26,
+	0x2e, 0x63, 0x6f, 0x64, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x72, 0x65, 0x72, 0x5f, 0x62,
+	0x6f, 0x74, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x2e, 0x52, 0x65, 0x73, 0x75,
+	0x6c, 0x74, 0x54, 0x79, 0x70, 0x65, 0x22, 0x00, 0x12, 0x5f, 0x0a, 0x0d, 0x47, 0x65, 0x74, 0x4d,
+	0x65, 0x72, 0x67, 0x65, 0x55, 0x73, 0x61, 0x67, 0x65, 0x12, 0x23, 0x2e, 0x63, 0x6f, 0x64, 0x65,
+	0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x72, 0x65, 0x72, 0x5f, 0x62, 0x6f, 0x74, 0x2e, 0x64, 0x61,
+	0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x2e, 0x4b, 0x65, 0x79, 0x54, 0x79, 0x70, 0x65, 0x1a, 0x27,
+	0x2e, 0x63, 0x6f, 0x64, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x72, 0x65, 0x72, 0x5f, 0x62,
+	0x6f, 0x74, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x2e, 0x55, 0x73, 0x61, 0x67,
+	0x65, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x22, 0x00, 0x12, 0x6d, 0x0a, 0x0f, 0x47, 0x65, 0x74,
+	0x4d, 0x6f, 0x6e, 0x74, 0x68, 0x6c, 0x79, 0x55, 0x73, 0x61, 0x67, 0x65, 0x12, 0x2f, 0x2e, 0x63,
+	0x6f, 0x64, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x72, 0x65, 0x72, 0x5f, 0x62, 0x6f, 0x74,
+	0x2e, 0x64, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x2e, 0x4d, 0x6f, 0x6e, 0x74, 0x68, 0x6c,
+	0x79, 0x55, 0x73, 0x61, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x27, 0x2e,
+	0x63, 0x6f, 0x64, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x72, 0x65, 0x72, 0x5f, 0x62, 0x6f,
+	0x74, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x2e, 0x55, 0x73, 0x61, 0x67, 0x65,
+	0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x22, 0x00, 0x42, 0x2e, 0x5a, 0x2c, 0x67, 0x69, 0x74, 0x68,
+	0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x63, 0x6f, 0x64, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63,
+	0x65, 0x72, 0x65, 0x72, 0x2d, 0x62, 0x6f, 0x74, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x67,
+	0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x64, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -219,28 +426,40 @@ func file_database_proto_rawDescGZIP() []byte {
 	return file_database_proto_rawDescData
 }
 
-var file_database_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_database_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
 var file_database_proto_goTypes = []any{
-	(*KeyType)(nil),        // 0: codesourcerer_bot.database.KeyType
-	(*KeyValType)(nil),     // 1: codesourcerer_bot.database.KeyValType
-	(*ResultType)(nil),     // 2: codesourcerer_bot.database.ResultType
-	(*CachedContents)(nil), // 3: codesourcerer_bot.shared.CachedContents
+	(*KeyType)(nil),             // 0: codesourcerer_bot.database.KeyType
+	(*KeyValType)(nil),          // 1: codesourcerer_bot.database.KeyValType
+	(*ResultType)(nil),          // 2: codesourcerer_bot.database.ResultType
+	(*UsageRecord)(nil),         // 3: codesourcerer_bot.database.UsageRecord
+	(*MonthlyUsageRequest)(nil), // 4: codesourcerer_bot.database.MonthlyUsageRequest
+	(*UsageReport)(nil),         // 5: codesourcerer_bot.database.UsageReport
+	(*CachedContents)(nil),      // 6: codesourcerer_bot.shared.CachedContents
+	(*TokenUsage)(nil),          // 7: codesourcerer_bot.shared.TokenUsage
 }
 var file_database_proto_depIdxs = []int32{
-	3, // 0: codesourcerer_bot.database.KeyValType.value:type_name -> codesourcerer_bot.shared.CachedContents
-	1, // 1: codesourcerer_bot.database.DatabaseService.Set:input_type -> codesourcerer_bot.database.KeyValType
-	0, // 2: codesourcerer_bot.database.DatabaseService.Get:input_type -> codesourcerer_bot.database.KeyType
-	0, // 3: codesourcerer_bot.database.DatabaseService.Delete:input_type -> codesourcerer_bot.database.KeyType
-	0, // 4: codesourcerer_bot.database.DatabaseService.IsRetriesExhauted:input_type -> codesourcerer_bot.database.KeyType
-	2, // 5: codesourcerer_bot.database.DatabaseService.Set:output_type -> codesourcerer_bot.database.ResultType
-	3, // 6: codesourcerer_bot.database.DatabaseService.Get:output_type -> codesourcerer_bot.shared.CachedContents
-	2, // 7: codesourcerer_bot.database.DatabaseService.Delete:output_type -> codesourcerer_bot.database.ResultType
-	2, // 8: codesourcerer_bot.database.DatabaseService.IsRetriesExhauted:output_type -> codesourcerer_bot.database.ResultType
-	5, // [5:9] is the sub-list for method output_type
-	1, // [1:5] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	6,  // 0: codesourcerer_bot.database.KeyValType.value:type_name -> codesourcerer_bot.shared.CachedContents
+	7,  // 1: codesourcerer_bot.database.UsageRecord.usage:type_name -> codesourcerer_bot.shared.TokenUsage
+	7,  // 2: codesourcerer_bot.database.UsageReport.usage:type_name -> codesourcerer_bot.shared.TokenUsage
+	1,  // 3: codesourcerer_bot.database.DatabaseService.Set:input_type -> codesourcerer_bot.database.KeyValType
+	0,  // 4: codesourcerer_bot.database.DatabaseService.Get:input_type -> codesourcerer_bot.database.KeyType
+	0,  // 5: codesourcerer_bot.database.DatabaseService.Delete:input_type -> codesourcerer_bot.database.KeyType
+	0,  // 6: codesourcerer_bot.database.DatabaseService.IsRetriesExhauted:input_type -> codesourcerer_bot.database.KeyType
+	3,  // 7: codesourcerer_bot.database.DatabaseService.RecordUsage:input_type -> codesourcerer_bot.database.UsageRecord
+	0,  // 8: codesourcerer_bot.database.DatabaseService.GetMergeUsage:input_type -> codesourcerer_bot.database.KeyType
+	4,  // 9: codesourcerer_bot.database.DatabaseService.GetMonthlyUsage:input_type -> codesourcerer_bot.database.MonthlyUsageRequest
+	2,  // 10: codesourcerer_bot.database.DatabaseService.Set:output_type -> codesourcerer_bot.database.ResultType
+	6,  // 11: codesourcerer_bot.database.DatabaseService.Get:output_type -> codesourcerer_bot.shared.CachedContents
+	2,  // 12: codesourcerer_bot.database.DatabaseService.Delete:output_type -> codesourcerer_bot.database.ResultType
+	2,  // 13: codesourcerer_bot.database.DatabaseService.IsRetriesExhauted:output_type -> codesourcerer_bot.database.ResultType
+	2,  // 14: codesourcerer_bot.database.DatabaseService.RecordUsage:output_type -> codesourcerer_bot.database.ResultType
+	5,  // 15: codesourcerer_bot.database.DatabaseService.GetMergeUsage:output_type -> codesourcerer_bot.database.UsageReport
+	5,  // 16: codesourcerer_bot.database.DatabaseService.GetMonthlyUsage:output_type -> codesourcerer_bot.database.UsageReport
+	10, // [10:17] is the sub-list for method output_type
+	3,  // [3:10] is the sub-list for method input_type
+	3,  // [3:3] is the sub-list for extension type_name
+	3,  // [3:3] is the sub-list for extension extendee
+	0,  // [0:3] is the sub-list for field type_name
 }
 
 func init() { file_database_proto_init() }
@@ -255,7 +474,7 @@ func file_database_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_database_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   3,
+			NumMessages:   6,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -23,6 +23,9 @@ const (
 	DatabaseService_Get_FullMethodName               = "/codesourcerer_bot.database.DatabaseService/Get"
 	DatabaseService_Delete_FullMethodName            = "/codesourcerer_bot.database.DatabaseService/Delete"
 	DatabaseService_IsRetriesExhauted_FullMethodName = "/codesourcerer_bot.database.DatabaseService/IsRetriesExhauted"
+	DatabaseService_RecordUsage_FullMethodName       = "/codesourcerer_bot.database.DatabaseService/RecordUsage"
+	DatabaseService_GetMergeUsage_FullMethodName     = "/codesourcerer_bot.database.DatabaseService/GetMergeUsage"
+	DatabaseService_GetMonthlyUsage_FullMethodName   = "/codesourcerer_bot.database.DatabaseService/GetMonthlyUsage"
 )
 
 // DatabaseServiceClient is the client API for DatabaseService service.
@@ -33,6 +36,9 @@ type DatabaseServiceClient interface {
 	Get(ctx context.Context, in *KeyType, opts ...grpc.CallOption) (*CachedContents, error)
 	Delete(ctx context.Context, in *KeyType, opts ...grpc.CallOption) (*ResultType, error)
 	IsRetriesExhauted(ctx context.Context, in *KeyType, opts ...grpc.CallOption) (*ResultType, error)
+	RecordUsage(ctx context.Context, in *UsageRecord, opts ...grpc.CallOption) (*ResultType, error)
+	GetMergeUsage(ctx context.Context, in *KeyType, opts ...grpc.CallOption) (*UsageReport, error)
+	GetMonthlyUsage(ctx context.Context, in *MonthlyUsageRequest, opts ...grpc.CallOption) (*UsageReport, error)
 }
 
 type databaseServiceClient struct {
@@ -83,6 +89,36 @@ func (c *databaseServiceClient) IsRetriesExhauted(ctx context.Context, in *KeyTy
 	return out, nil
 }
 
+func (c *databaseServiceClient) RecordUsage(ctx context.Context, in *UsageRecord, opts ...grpc.CallOption) (*ResultType, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ResultType)
+	err := c.cc.Invoke(ctx, DatabaseService_RecordUsage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *databaseServiceClient) GetMergeUsage(ctx context.Context, in *KeyType, opts ...grpc.CallOption) (*UsageReport, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UsageReport)
+	err := c.cc.Invoke(ctx, DatabaseService_GetMergeUsage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *databaseServiceClient) GetMonthlyUsage(ctx context.Context, in *MonthlyUsageRequest, opts ...grpc.CallOption) (*UsageReport, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UsageReport)
+	err := c.cc.Invoke(ctx, DatabaseService_GetMonthlyUsage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // DatabaseServiceServer is the server API for DatabaseService service.
 // All implementations must embed UnimplementedDatabaseServiceServer
 // for forward compatibility.
@@ -91,6 +127,9 @@ type DatabaseServiceServer interface {
 	Get(context.Context, *KeyType) (*CachedContents, error)
 	Delete(context.Context, *KeyType) (*ResultType, error)
 	IsRetriesExhauted(context.Context, *KeyType) (*ResultType, error)
+	RecordUsage(context.Context, *UsageRecord) (*ResultType, error)
+	GetMergeUsage(context.Context, *KeyType) (*UsageReport, error)
+	GetMonthlyUsage(context.Context, *MonthlyUsageRequest) (*UsageReport, error)
 	mustEmbedUnimplementedDatabaseServiceServer()
 }
 
@@ -113,6 +152,15 @@ func (UnimplementedDatabaseServiceServer) Delete(context.Context, *KeyType) (*Re
 func (UnimplementedDatabaseServiceServer) IsRetriesExhauted(context.Context, *KeyType) (*ResultType, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method IsRetriesExhauted not implemented")
 }
+func (UnimplementedDatabaseServiceServer) RecordUsage(context.Context, *UsageRecord) (*ResultType, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RecordUsage not implemented")
+}
+func (UnimplementedDatabaseServiceServer) GetMergeUsage(context.Context, *KeyType) (*UsageReport, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMergeUsage not implemented")
+}
+func (UnimplementedDatabaseServiceServer) GetMonthlyUsage(context.Context, *MonthlyUsageRequest) (*UsageReport, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMonthlyUsage not implemented")
+}
 func (UnimplementedDatabaseServiceServer) mustEmbedUnimplementedDatabaseServiceServer() {}
 func (UnimplementedDatabaseServiceServer) testEmbeddedByValue()                         {}
 
@@ -206,6 +254,60 @@ func _DatabaseService_IsRetriesExhauted_Handler(srv interface{}, ctx context.Con
 	return interceptor(ctx, in, info, handler)
 }
 
+func _DatabaseService_RecordUsage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UsageRecord)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DatabaseServiceServer).RecordUsage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DatabaseService_RecordUsage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DatabaseServiceServer).RecordUsage(ctx, req.(*UsageRecord))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DatabaseService_GetMergeUsage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(KeyType)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DatabaseServiceServer).GetMergeUsage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DatabaseService_GetMergeUsage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DatabaseServiceServer).GetMergeUsage(ctx, req.(*KeyType))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DatabaseService_GetMonthlyUsage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MonthlyUsageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DatabaseServiceServer).GetMonthlyUsage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DatabaseService_GetMonthlyUsage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DatabaseServiceServer).GetMonthlyUsage(ctx, req.(*MonthlyUsageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // DatabaseService_ServiceDesc is the grpc.ServiceDesc for DatabaseService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -229,6 +331,18 @@ var DatabaseService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "IsRetriesExhauted",
 			Handler:    _DatabaseService_IsRetriesExhauted_Handler,
 		},
+		{
+			MethodName: "RecordUsage",
+			Handler:    _DatabaseService_RecordUsage_Handler,
+		},
+		{
+			MethodName: "GetMergeUsage",
+			Handler:    _DatabaseService_GetMergeUsage_Handler,
+		},
+		{
+			MethodName: "GetMonthlyUsage",
+			Handler:    _DatabaseService_GetMonthlyUsage_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "database.proto",
@@ -20,30 +20,31 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
-type BasicConfig struct {
-	state            protoimpl.MessageState `protogen:"open.v1"`
-	TestDirectory    string                 `protobuf:"bytes,1,opt,name=test_directory,json=testDirectory,proto3" json:"test_directory,omitempty"`
-	Comments         bool                   `protobuf:"varint,2,opt,name=comments,proto3" json:"comments,omitempty"`
-	TestingFramework string                 `protobuf:"bytes,3,opt,name=testing_framework,json=testingFramework,proto3" json:"testing_framework,omitempty"`
-	WaterMark        bool                   `protobuf:"varint,4,opt,name=water_mark,json=waterMark,proto3" json:"water_mark,omitempty"`
-	unknownFields    protoimpl.UnknownFields
-	sizeCache        protoimpl.SizeCache
-}
-
-func (x *BasicConfig) Reset() {
-	*x = BasicConfig{}
+type GithubContextRequest struct {
+	state         protoimpl.MessageState         `protogen:"open.v1"`
+	MergeId       string                         `protobuf:"bytes,1,opt,name=merge_id,json=mergeId,proto3" json:"merge_id,omitempty"`
+	Context       string                         `protobuf:"bytes,2,opt,name=context,proto3" json:"context,omitempty"`
+	Config        *Configuration                 `protobuf:"bytes,3,opt,name=config,proto3" json:"config,omitempty"`
+	Files         []*SourceFilePayload           `protobuf:"bytes,4,rep,name=files,proto3" json:"files,omitempty"`
+	Dependencies  []*SourceFileDependencyPayload `protobuf:"bytes,5,rep,name=dependencies,proto3" json:"dependencies,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GithubContextRequest) Reset() {
+	*x = GithubContextRequest{}
 	mi := &file_gen_ai_proto_msgTypes[0]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *BasicConfig) String() string {
+func (x *GithubContextRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*BasicConfig) ProtoMessage() {}
+func (*GithubContextRequest) ProtoMessage() {}
 
-func (x *BasicConfig) ProtoReflect() protoreflect.Message {
+func (x *GithubContextRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_gen_ai_proto_msgTypes[0]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -55,61 +56,68 @@ func (x *BasicConfig) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use BasicConfig.ProtoReflect.Descriptor instead.
-func (*BasicConfig) Descriptor() ([]byte, []int) {
+// Deprecated: Use GithubContextRequest.ProtoReflect.Descriptor instead.
+func (*GithubContextRequest) Descriptor() ([]byte, []int) {
 	return file_gen_ai_proto_rawDescGZIP(), []int{0}
 }
 
-func (x *BasicConfig) GetTestDirectory() string {
+func (x *GithubContextRequest) GetMergeId() string {
 	if x != nil {
-		return x.TestDirectory
+		return x.MergeId
 	}
 	return ""
 }
 
-func (x *BasicConfig) GetComments() bool {
+func (x *GithubContextRequest) GetContext() string {
 	if x != nil {
-		return x.Comments
+		return x.Context
 	}
-	return false
+	return ""
 }
 
-func (x *BasicConfig) GetTestingFramework() string {
+func (x *GithubContextRequest) GetConfig() *Configuration {
 	if x != nil {
-		return x.TestingFramework
+		return x.Config
 	}
-	return ""
+	return nil
 }
 
-func (x *BasicConfig) GetWaterMark() bool {
+func (x *GithubContextRequest) GetFiles() []*SourceFilePayload {
 	if x != nil {
-		return x.WaterMark
+		return x.Files
 	}
-	return false
+	return nil
 }
 
-type Configuration struct {
+func (x *GithubContextRequest) GetDependencies() []*SourceFileDependencyPayload {
+	if x != nil {
+		return x.Dependencies
+	}
+	return nil
+}
+
+type SkippedFile struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Configuration *BasicConfig           `protobuf:"bytes,1,opt,name=configuration,proto3" json:"configuration,omitempty"`
-	Extras        map[string]string      `protobuf:"bytes,2,rep,name=extras,proto3" json:"extras,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Path          string                 `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Configuration) Reset() {
-	*x = Configuration{}
+func (x *SkippedFile) Reset() {
+	*x = SkippedFile{}
 	mi := &file_gen_ai_proto_msgTypes[1]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Configuration) String() string {
+func (x *SkippedFile) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Configuration) ProtoMessage() {}
+func (*SkippedFile) ProtoMessage() {}
 
-func (x *Configuration) ProtoReflect() protoreflect.Message {
+func (x *SkippedFile) ProtoReflect() protoreflect.Message {
 	mi := &file_gen_ai_proto_msgTypes[1]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -121,49 +129,50 @@ func (x *Configuration) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Configuration.ProtoReflect.Descriptor instead.
-func (*Configuration) Descriptor() ([]byte, []int) {
+// Deprecated: Use SkippedFile.ProtoReflect.Descriptor instead.
+func (*SkippedFile) Descriptor() ([]byte, []int) {
 	return file_gen_ai_proto_rawDescGZIP(), []int{1}
 }
 
-func (x *Configuration) GetConfiguration() *BasicConfig {
+func (x *SkippedFile) GetPath() string {
 	if x != nil {
-		return x.Configuration
+		return x.Path
 	}
-	return nil
+	return ""
 }
 
-func (x *Configuration) GetExtras() map[string]string {
+func (x *SkippedFile) GetReason() string {
 	if x != nil {
-		return x.Extras
+		return x.Reason
 	}
-	return nil
+	return ""
 }
 
-type GithubContextRequest struct {
+type GeneratedTestsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	MergeId       string                 `protobuf:"bytes,1,opt,name=merge_id,json=mergeId,proto3" json:"merge_id,omitempty"`
-	Context       string                 `protobuf:"bytes,2,opt,name=context,proto3" json:"context,omitempty"`
-	Config        *Configuration         `protobuf:"bytes,3,opt,name=config,proto3" json:"config,omitempty"`
-	Files         []*SourceFilePayload   `protobuf:"bytes,4,rep,name=files,proto3" json:"files,omitempty"`
+	Tests         []*TestFilePayload     `protobuf:"bytes,1,rep,name=tests,proto3" json:"tests,omitempty"`
+	Model         string                 `protobuf:"bytes,2,opt,name=model,proto3" json:"model,omitempty"`
+	SkippedFiles  []*SkippedFile         `protobuf:"bytes,3,rep,name=skipped_files,json=skippedFiles,proto3" json:"skipped_files,omitempty"`
+	PromptVersion string                 `protobuf:"bytes,4,opt,name=prompt_version,json=promptVersion,proto3" json:"prompt_version,omitempty"`
+	Usage         []*TokenUsage          `protobuf:"bytes,5,rep,name=usage,proto3" json:"usage,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GithubContextRequest) Reset() {
-	*x = GithubContextRequest{}
+func (x *GeneratedTestsResponse) Reset() {
+	*x = GeneratedTestsResponse{}
 	mi := &file_gen_ai_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GithubContextRequest) String() string {
+func (x *GeneratedTestsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GithubContextRequest) ProtoMessage() {}
+func (*GeneratedTestsResponse) ProtoMessage() {}
 
-func (x *GithubContextRequest) ProtoReflect() protoreflect.Message {
+func (x *GeneratedTestsResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_gen_ai_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -175,60 +184,76 @@ func (x *GithubContextRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GithubContextRequest.ProtoReflect.Descriptor instead.
-func (*GithubContextRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use GeneratedTestsResponse.ProtoReflect.Descriptor instead.
+func (*GeneratedTestsResponse) Descriptor() ([]byte, []int) {
 	return file_gen_ai_proto_rawDescGZIP(), []int{2}
 }
 
-func (x *GithubContextRequest) GetMergeId() string {
+func (x *GeneratedTestsResponse) GetTests() []*TestFilePayload {
 	if x != nil {
-		return x.MergeId
+		return x.Tests
 	}
-	return ""
+	return nil
 }
 
-func (x *GithubContextRequest) GetContext() string {
+func (x *GeneratedTestsResponse) GetModel() string {
 	if x != nil {
-		return x.Context
+		return x.Model
 	}
 	return ""
 }
 
-func (x *GithubContextRequest) GetConfig() *Configuration {
+func (x *GeneratedTestsResponse) GetSkippedFiles() []*SkippedFile {
 	if x != nil {
-		return x.Config
+		return x.SkippedFiles
 	}
 	return nil
 }
 
-func (x *GithubContextRequest) GetFiles() []*SourceFilePayload {
+func (x *GeneratedTestsResponse) GetPromptVersion() string {
 	if x != nil {
-		return x.Files
+		return x.PromptVersion
+	}
+	return ""
+}
+
+func (x *GeneratedTestsResponse) GetUsage() []*TokenUsage {
+	if x != nil {
+		return x.Usage
 	}
 	return nil
 }
 
-type GeneratedTestsResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Tests         []*TestFilePayload     `protobuf:"bytes,1,rep,name=tests,proto3" json:"tests,omitempty"`
+type TestFileResult struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Path  string                 `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	// Types that are valid to be assigned to Result:
+	//
+	//	*TestFileResult_Test
+	//	*TestFileResult_Error
+	Result        isTestFileResult_Result `protobuf_oneof:"result"`
+	Model         string                  `protobuf:"bytes,4,opt,name=model,proto3" json:"model,omitempty"`
+	PromptVersion string                  `protobuf:"bytes,5,opt,name=prompt_version,json=promptVersion,proto3" json:"prompt_version,omitempty"`
+	// usage is sent with the first result of each batch and covers the whole batch
+	Usage         []*TokenUsage `protobuf:"bytes,6,rep,name=usage,proto3" json:"usage,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GeneratedTestsResponse) Reset() {
-	*x = GeneratedTestsResponse{}
+func (x *TestFileResult) Reset() {
+	*x = TestFileResult{}
 	mi := &file_gen_ai_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GeneratedTestsResponse) String() string {
+func (x *TestFileResult) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GeneratedTestsResponse) ProtoMessage() {}
+func (*TestFileResult) ProtoMessage() {}
 
-func (x *GeneratedTestsResponse) ProtoReflect() protoreflect.Message {
+func (x *TestFileResult) ProtoReflect() protoreflect.Message {
 	mi := &file_gen_ai_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -240,18 +265,80 @@ func (x *GeneratedTestsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GeneratedTestsResponse.ProtoReflect.Descriptor instead.
-func (*GeneratedTestsResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use TestFileResult.ProtoReflect.Descriptor instead.
+func (*TestFileResult) Descriptor() ([]byte, []int) {
 	return file_gen_ai_proto_rawDescGZIP(), []int{3}
 }
 
-func (x *GeneratedTestsResponse) GetTests() []*TestFilePayload {
+func (x *TestFileResult) GetPath() string {
 	if x != nil {
-		return x.Tests
+		return x.Path
+	}
+	return ""
+}
+
+func (x *TestFileResult) GetResult() isTestFileResult_Result {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+func (x *TestFileResult) GetTest() *TestFilePayload {
+	if x != nil {
+		if x, ok := x.Result.(*TestFileResult_Test); ok {
+			return x.Test
+		}
+	}
+	return nil
+}
+
+func (x *TestFileResult) GetError() string {
+	if x != nil {
+		if x, ok := x.Result.(*TestFileResult_Error); ok {
+			return x.Error
+		}
+	}
+	return ""
+}
+
+func (x *TestFileResult) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
+func (x *TestFileResult) GetPromptVersion() string {
+	if x != nil {
+		return x.PromptVersion
+	}
+	return ""
+}
+
+func (x *TestFileResult) GetUsage() []*TokenUsage {
+	if x != nil {
+		return x.Usage
 	}
 	return nil
 }
 
+type isTestFileResult_Result interface {
+	isTestFileResult_Result()
+}
+
+type TestFileResult_Test struct {
+	Test *TestFilePayload `protobuf:"bytes,2,opt,name=test,proto3,oneof"`
+}
+
+type TestFileResult_Error struct {
+	Error string `protobuf:"bytes,3,opt,name=error,proto3,oneof"`
+}
+
+func (*TestFileResult_Test) isTestFileResult_Result() {}
+
+func (*TestFileResult_Error) isTestFileResult_Result() {}
+
 type RetryMechanismPayload struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Cache         *CachedContents        `protobuf:"bytes,1,opt,name=cache,proto3" json:"cache,omitempty"`
@@ -310,77 +397,97 @@ var file_gen_ai_proto_rawDesc = []byte{
 	0x0a, 0x0c, 0x67, 0x65, 0x6e, 0x2d, 0x61, 0x69, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x17,
 	0x63, 0x6f, 0x64, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x72, 0x65, 0x72, 0x5f, 0x62, 0x6f,
 	0x74, 0x2e, 0x67, 0x65, 0x6e, 0x61, 0x69, 0x1a, 0x0c, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x9c, 0x01, 0x0a, 0x0b, 0x42, 0x61, 0x73, 0x69, 0x63, 0x43,
-	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x25, 0x0a, 0x0e, 0x74, 0x65, 0x73, 0x74, 0x5f, 0x64, 0x69,
-	0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x74,
-	0x65, 0x73, 0x74, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x79, 0x12, 0x1a, 0x0a, 0x08,
-	0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08,
-	0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x2b, 0x0a, 0x11, 0x74, 0x65, 0x73, 0x74,
-	0x69, 0x6e, 0x67, 0x5f, 0x66, 0x72, 0x61, 0x6d, 0x65, 0x77, 0x6f, 0x72, 0x6b, 0x18, 0x03, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x10, 0x74, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x67, 0x46, 0x72, 0x61, 0x6d,
-	0x65, 0x77, 0x6f, 0x72, 0x6b, 0x12, 0x1d, 0x0a, 0x0a, 0x77, 0x61, 0x74, 0x65, 0x72, 0x5f, 0x6d,
-	0x61, 0x72, 0x6b, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x77, 0x61, 0x74, 0x65, 0x72,
-	0x4d, 0x61, 0x72, 0x6b, 0x22, 0xe2, 0x01, 0x0a, 0x0d, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75,
-	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x4a, 0x0a, 0x0d, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67,
-	0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xaa, 0x02, 0x0a, 0x14, 0x47, 0x69, 0x74, 0x68, 0x75, 0x62,
+	0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x19,
+	0x0a, 0x08, 0x6d, 0x65, 0x72, 0x67, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x07, 0x6d, 0x65, 0x72, 0x67, 0x65, 0x49, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6e,
+	0x74, 0x65, 0x78, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6f, 0x6e, 0x74,
+	0x65, 0x78, 0x74, 0x12, 0x3f, 0x0a, 0x06, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x27, 0x2e, 0x63, 0x6f, 0x64, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65,
+	0x72, 0x65, 0x72, 0x5f, 0x62, 0x6f, 0x74, 0x2e, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x2e, 0x43,
+	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x06, 0x63, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x12, 0x41, 0x0a, 0x05, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x18, 0x04, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x2b, 0x2e, 0x63, 0x6f, 0x64, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65,
+	0x72, 0x65, 0x72, 0x5f, 0x62, 0x6f, 0x74, 0x2e, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x2e, 0x53,
+	0x6f, 0x75, 0x72, 0x63, 0x65, 0x46, 0x69, 0x6c, 0x65, 0x50, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64,
+	0x52, 0x05, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x12, 0x59, 0x0a, 0x0c, 0x64, 0x65, 0x70, 0x65, 0x6e,
+	0x64, 0x65, 0x6e, 0x63, 0x69, 0x65, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x35, 0x2e,
 	0x63, 0x6f, 0x64, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x72, 0x65, 0x72, 0x5f, 0x62, 0x6f,
-	0x74, 0x2e, 0x67, 0x65, 0x6e, 0x61, 0x69, 0x2e, 0x42, 0x61, 0x73, 0x69, 0x63, 0x43, 0x6f, 0x6e,
-	0x66, 0x69, 0x67, 0x52, 0x0d, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69,
-	0x6f, 0x6e, 0x12, 0x4a, 0x0a, 0x06, 0x65, 0x78, 0x74, 0x72, 0x61, 0x73, 0x18, 0x02, 0x20, 0x03,
-	0x28, 0x0b, 0x32, 0x32, 0x2e, 0x63, 0x6f, 0x64, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x72,
-	0x65, 0x72, 0x5f, 0x62, 0x6f, 0x74, 0x2e, 0x67, 0x65, 0x6e, 0x61, 0x69, 0x2e, 0x43, 0x6f, 0x6e,
-	0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x45, 0x78, 0x74, 0x72, 0x61,
-	0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x06, 0x65, 0x78, 0x74, 0x72, 0x61, 0x73, 0x1a, 0x39,
-	0x0a, 0x0b, 0x45, 0x78, 0x74, 0x72, 0x61, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a,
-	0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12,
-	0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
-	0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xce, 0x01, 0x0a, 0x14, 0x47, 0x69,
+	0x74, 0x2e, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x2e, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x46,
+	0x69, 0x6c, 0x65, 0x44, 0x65, 0x70, 0x65, 0x6e, 0x64, 0x65, 0x6e, 0x63, 0x79, 0x50, 0x61, 0x79,
+	0x6c, 0x6f, 0x61, 0x64, 0x52, 0x0c, 0x64, 0x65, 0x70, 0x65, 0x6e, 0x64, 0x65, 0x6e, 0x63, 0x69,
+	0x65, 0x73, 0x22, 0x39, 0x0a, 0x0b, 0x53, 0x6b, 0x69, 0x70, 0x70, 0x65, 0x64, 0x46, 0x69, 0x6c,
+	0x65, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x70, 0x61, 0x74, 0x68, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x22, 0x9d, 0x02,
+	0x0a, 0x16, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x64, 0x54, 0x65, 0x73, 0x74, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3f, 0x0a, 0x05, 0x74, 0x65, 0x73, 0x74,
+	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x29, 0x2e, 0x63, 0x6f, 0x64, 0x65, 0x73, 0x6f,
+	0x75, 0x72, 0x63, 0x65, 0x72, 0x65, 0x72, 0x5f, 0x62, 0x6f, 0x74, 0x2e, 0x73, 0x68, 0x61, 0x72,
+	0x65, 0x64, 0x2e, 0x54, 0x65, 0x73, 0x74, 0x46, 0x69, 0x6c, 0x65, 0x50, 0x61, 0x79, 0x6c, 0x6f,
+	0x61, 0x64, 0x52, 0x05, 0x74, 0x65, 0x73, 0x74, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x6d, 0x6f, 0x64,
+	0x65, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x12,
+	0x49, 0x0a, 0x0d, 0x73, 0x6b, 0x69, 0x70, 0x70, 0x65, 0x64, 0x5f, 0x66, 0x69, 0x6c, 0x65, 0x73,
+	0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x63, 0x6f, 0x64, 0x65, 0x73, 0x6f, 0x75,
+	0x72, 0x63, 0x65, 0x72, 0x65, 0x72, 0x5f, 0x62, 0x6f, 0x74, 0x2e, 0x67, 0x65, 0x6e, 0x61, 0x69,
+	0x2e, 0x53, 0x6b, 0x69, 0x70, 0x70, 0x65, 0x64, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x0c, 0x73, 0x6b,
+	0x69, 0x70, 0x70, 0x65, 0x64, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x12, 0x25, 0x0a, 0x0e, 0x70, 0x72,
+	0x6f, 0x6d, 0x70, 0x74, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0d, 0x70, 0x72, 0x6f, 0x6d, 0x70, 0x74, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f,
+	0x6e, 0x12, 0x3a, 0x0a, 0x05, 0x75, 0x73, 0x61, 0x67, 0x65, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x24, 0x2e, 0x63, 0x6f, 0x64, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x72, 0x65, 0x72,
+	0x5f, 0x62, 0x6f, 0x74, 0x2e, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x2e, 0x54, 0x6f, 0x6b, 0x65,
+	0x6e, 0x55, 0x73, 0x61, 0x67, 0x65, 0x52, 0x05, 0x75, 0x73, 0x61, 0x67, 0x65, 0x22, 0x80, 0x02,
+	0x0a, 0x0e, 0x54, 0x65, 0x73, 0x74, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74,
+	0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x70, 0x61, 0x74, 0x68, 0x12, 0x3f, 0x0a, 0x04, 0x74, 0x65, 0x73, 0x74, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x29, 0x2e, 0x63, 0x6f, 0x64, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x72,
+	0x65, 0x72, 0x5f, 0x62, 0x6f, 0x74, 0x2e, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x2e, 0x54, 0x65,
+	0x73, 0x74, 0x46, 0x69, 0x6c, 0x65, 0x50, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x48, 0x00, 0x52,
+	0x04, 0x74, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x14, 0x0a,
+	0x05, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6d, 0x6f,
+	0x64, 0x65, 0x6c, 0x12, 0x25, 0x0a, 0x0e, 0x70, 0x72, 0x6f, 0x6d, 0x70, 0x74, 0x5f, 0x76, 0x65,
+	0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x70, 0x72, 0x6f,
+	0x6d, 0x70, 0x74, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x3a, 0x0a, 0x05, 0x75, 0x73,
+	0x61, 0x67, 0x65, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x63, 0x6f, 0x64, 0x65,
+	0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x72, 0x65, 0x72, 0x5f, 0x62, 0x6f, 0x74, 0x2e, 0x73, 0x68,
+	0x61, 0x72, 0x65, 0x64, 0x2e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x55, 0x73, 0x61, 0x67, 0x65, 0x52,
+	0x05, 0x75, 0x73, 0x61, 0x67, 0x65, 0x42, 0x08, 0x0a, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74,
+	0x22, 0x6b, 0x0a, 0x15, 0x52, 0x65, 0x74, 0x72, 0x79, 0x4d, 0x65, 0x63, 0x68, 0x61, 0x6e, 0x69,
+	0x73, 0x6d, 0x50, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x12, 0x3e, 0x0a, 0x05, 0x63, 0x61, 0x63,
+	0x68, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x28, 0x2e, 0x63, 0x6f, 0x64, 0x65, 0x73,
+	0x6f, 0x75, 0x72, 0x63, 0x65, 0x72, 0x65, 0x72, 0x5f, 0x62, 0x6f, 0x74, 0x2e, 0x73, 0x68, 0x61,
+	0x72, 0x65, 0x64, 0x2e, 0x43, 0x61, 0x63, 0x68, 0x65, 0x64, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e,
+	0x74, 0x73, 0x52, 0x05, 0x63, 0x61, 0x63, 0x68, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6c, 0x6f, 0x67,
+	0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x6c, 0x6f, 0x67, 0x73, 0x32, 0xf3, 0x02,
+	0x0a, 0x0c, 0x47, 0x65, 0x6e, 0x41, 0x69, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x75,
+	0x0a, 0x11, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x54, 0x65, 0x73, 0x74, 0x46, 0x69,
+	0x6c, 0x65, 0x73, 0x12, 0x2d, 0x2e, 0x63, 0x6f, 0x64, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65,
+	0x72, 0x65, 0x72, 0x5f, 0x62, 0x6f, 0x74, 0x2e, 0x67, 0x65, 0x6e, 0x61, 0x69, 0x2e, 0x47, 0x69,
 	0x74, 0x68, 0x75, 0x62, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x6d, 0x65, 0x72, 0x67, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x72, 0x67, 0x65, 0x49, 0x64, 0x12, 0x18, 0x0a,
-	0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
-	0x63, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x12, 0x3e, 0x0a, 0x06, 0x63, 0x6f, 0x6e, 0x66, 0x69,
-	0x67, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x26, 0x2e, 0x63, 0x6f, 0x64, 0x65, 0x73, 0x6f,
+	0x73, 0x74, 0x1a, 0x2f, 0x2e, 0x63, 0x6f, 0x64, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x72,
+	0x65, 0x72, 0x5f, 0x62, 0x6f, 0x74, 0x2e, 0x67, 0x65, 0x6e, 0x61, 0x69, 0x2e, 0x47, 0x65, 0x6e,
+	0x65, 0x72, 0x61, 0x74, 0x65, 0x64, 0x54, 0x65, 0x73, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x7d, 0x0a, 0x18, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74,
+	0x65, 0x52, 0x65, 0x74, 0x72, 0x69, 0x65, 0x64, 0x54, 0x65, 0x73, 0x74, 0x46, 0x69, 0x6c, 0x65,
+	0x73, 0x12, 0x2e, 0x2e, 0x63, 0x6f, 0x64, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x72, 0x65,
+	0x72, 0x5f, 0x62, 0x6f, 0x74, 0x2e, 0x67, 0x65, 0x6e, 0x61, 0x69, 0x2e, 0x52, 0x65, 0x74, 0x72,
+	0x79, 0x4d, 0x65, 0x63, 0x68, 0x61, 0x6e, 0x69, 0x73, 0x6d, 0x50, 0x61, 0x79, 0x6c, 0x6f, 0x61,
+	0x64, 0x1a, 0x2f, 0x2e, 0x63, 0x6f, 0x64, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x72, 0x65,
+	0x72, 0x5f, 0x62, 0x6f, 0x74, 0x2e, 0x67, 0x65, 0x6e, 0x61, 0x69, 0x2e, 0x47, 0x65, 0x6e, 0x65,
+	0x72, 0x61, 0x74, 0x65, 0x64, 0x54, 0x65, 0x73, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x22, 0x00, 0x12, 0x6d, 0x0a, 0x0f, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x54, 0x65,
+	0x73, 0x74, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x12, 0x2d, 0x2e, 0x63, 0x6f, 0x64, 0x65, 0x73, 0x6f,
 	0x75, 0x72, 0x63, 0x65, 0x72, 0x65, 0x72, 0x5f, 0x62, 0x6f, 0x74, 0x2e, 0x67, 0x65, 0x6e, 0x61,
-	0x69, 0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52,
-	0x06, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x41, 0x0a, 0x05, 0x66, 0x69, 0x6c, 0x65, 0x73,
-	0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2b, 0x2e, 0x63, 0x6f, 0x64, 0x65, 0x73, 0x6f, 0x75,
-	0x72, 0x63, 0x65, 0x72, 0x65, 0x72, 0x5f, 0x62, 0x6f, 0x74, 0x2e, 0x73, 0x68, 0x61, 0x72, 0x65,
-	0x64, 0x2e, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x46, 0x69, 0x6c, 0x65, 0x50, 0x61, 0x79, 0x6c,
-	0x6f, 0x61, 0x64, 0x52, 0x05, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x22, 0x59, 0x0a, 0x16, 0x47, 0x65,
-	0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x64, 0x54, 0x65, 0x73, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3f, 0x0a, 0x05, 0x74, 0x65, 0x73, 0x74, 0x73, 0x18, 0x01, 0x20,
-	0x03, 0x28, 0x0b, 0x32, 0x29, 0x2e, 0x63, 0x6f, 0x64, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65,
-	0x72, 0x65, 0x72, 0x5f, 0x62, 0x6f, 0x74, 0x2e, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x2e, 0x54,
-	0x65, 0x73, 0x74, 0x46, 0x69, 0x6c, 0x65, 0x50, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x52, 0x05,
-	0x74, 0x65, 0x73, 0x74, 0x73, 0x22, 0x6b, 0x0a, 0x15, 0x52, 0x65, 0x74, 0x72, 0x79, 0x4d, 0x65,
-	0x63, 0x68, 0x61, 0x6e, 0x69, 0x73, 0x6d, 0x50, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x12, 0x3e,
-	0x0a, 0x05, 0x63, 0x61, 0x63, 0x68, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x28, 0x2e,
-	0x63, 0x6f, 0x64, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x72, 0x65, 0x72, 0x5f, 0x62, 0x6f,
-	0x74, 0x2e, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x2e, 0x43, 0x61, 0x63, 0x68, 0x65, 0x64, 0x43,
-	0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x05, 0x63, 0x61, 0x63, 0x68, 0x65, 0x12, 0x12,
-	0x0a, 0x04, 0x6c, 0x6f, 0x67, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x6c, 0x6f,
-	0x67, 0x73, 0x32, 0x84, 0x02, 0x0a, 0x0c, 0x47, 0x65, 0x6e, 0x41, 0x69, 0x53, 0x65, 0x72, 0x76,
-	0x69, 0x63, 0x65, 0x12, 0x75, 0x0a, 0x11, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x54,
-	0x65, 0x73, 0x74, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x12, 0x2d, 0x2e, 0x63, 0x6f, 0x64, 0x65, 0x73,
-	0x6f, 0x75, 0x72, 0x63, 0x65, 0x72, 0x65, 0x72, 0x5f, 0x62, 0x6f, 0x74, 0x2e, 0x67, 0x65, 0x6e,
-	0x61, 0x69, 0x2e, 0x47, 0x69, 0x74, 0x68, 0x75, 0x62, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2f, 0x2e, 0x63, 0x6f, 0x64, 0x65, 0x73, 0x6f,
-	0x75, 0x72, 0x63, 0x65, 0x72, 0x65, 0x72, 0x5f, 0x62, 0x6f, 0x74, 0x2e, 0x67, 0x65, 0x6e, 0x61,
-	0x69, 0x2e, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x64, 0x54, 0x65, 0x73, 0x74, 0x73,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x7d, 0x0a, 0x18, 0x47, 0x65,
-	0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x52, 0x65, 0x74, 0x72, 0x69, 0x65, 0x64, 0x54, 0x65, 0x73,
-	0x74, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x12, 0x2e, 0x2e, 0x63, 0x6f, 0x64, 0x65, 0x73, 0x6f, 0x75,
-	0x72, 0x63, 0x65, 0x72, 0x65, 0x72, 0x5f, 0x62, 0x6f, 0x74, 0x2e, 0x67, 0x65, 0x6e, 0x61, 0x69,
-	0x2e, 0x52, 0x65, 0x74, 0x72, 0x79, 0x4d, 0x65, 0x63, 0x68, 0x61, 0x6e, 0x69, 0x73, 0x6d, 0x50,
-	0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x1a, 0x2f, 0x2e, 0x63, 0x6f, 0x64, 0x65, 0x73, 0x6f, 0x75,
+	0x69, 0x2e, 0x47, 0x69, 0x74, 0x68, 0x75, 0x62, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x27, 0x2e, 0x63, 0x6f, 0x64, 0x65, 0x73, 0x6f, 0x75,
 	0x72, 0x63, 0x65, 0x72, 0x65, 0x72, 0x5f, 0x62, 0x6f, 0x74, 0x2e, 0x67, 0x65, 0x6e, 0x61, 0x69,
-	0x2e, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x64, 0x54, 0x65, 0x73, 0x74, 0x73, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x42, 0x2e, 0x5a, 0x2c, 0x67, 0x69, 0x74,
-	0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x63, 0x6f, 0x64, 0x65, 0x73, 0x6f, 0x75, 0x72,
-	0x63, 0x65, 0x72, 0x65, 0x72, 0x2d, 0x62, 0x6f, 0x74, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f,
-	0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x64, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x33,
+	0x2e, 0x54, 0x65, 0x73, 0x74, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x22,
+	0x00, 0x30, 0x01, 0x42, 0x2e, 0x5a, 0x2c, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f,
+	0x6d, 0x2f, 0x63, 0x6f, 0x64, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x72, 0x65, 0x72, 0x2d,
+	0x62, 0x6f, 0x74, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61,
+	0x74, 0x65, 0x64, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -395,34 +502,41 @@ func file_gen_ai_proto_rawDescGZIP() []byte {
 	return file_gen_ai_proto_rawDescData
 }
 
-var file_gen_ai_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_gen_ai_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
 var file_gen_ai_proto_goTypes = []any{
-	(*BasicConfig)(nil),            // 0: codesourcerer_bot.genai.BasicConfig
-	(*Configuration)(nil),          // 1: codesourcerer_bot.genai.Configuration
-	(*GithubContextRequest)(nil),   // 2: codesourcerer_bot.genai.GithubContextRequest
-	(*GeneratedTestsResponse)(nil), // 3: codesourcerer_bot.genai.GeneratedTestsResponse
-	(*RetryMechanismPayload)(nil),  // 4: codesourcerer_bot.genai.RetryMechanismPayload
-	nil,                            // 5: codesourcerer_bot.genai.Configuration.ExtrasEntry
-	(*SourceFilePayload)(nil),      // 6: codesourcerer_bot.shared.SourceFilePayload
-	(*TestFilePayload)(nil),        // 7: codesourcerer_bot.shared.TestFilePayload
-	(*CachedContents)(nil),         // 8: codesourcerer_bot.shared.CachedContents
+	(*GithubContextRequest)(nil),        // 0: codesourcerer_bot.genai.GithubContextRequest
+	(*SkippedFile)(nil),                 // 1: codesourcerer_bot.genai.SkippedFile
+	(*GeneratedTestsResponse)(nil),      // 2: codesourcerer_bot.genai.GeneratedTestsResponse
+	(*TestFileResult)(nil),              // 3: codesourcerer_bot.genai.TestFileResult
+	(*RetryMechanismPayload)(nil),       // 4: codesourcerer_bot.genai.RetryMechanismPayload
+	(*Configuration)(nil),               // 5: codesourcerer_bot.shared.Configuration
+	(*SourceFilePayload)(nil),           // 6: codesourcerer_bot.shared.SourceFilePayload
+	(*SourceFileDependencyPayload)(nil), // 7: codesourcerer_bot.shared.SourceFileDependencyPayload
+	(*TestFilePayload)(nil),             // 8: codesourcerer_bot.shared.TestFilePayload
+	(*TokenUsage)(nil),                  // 9: codesourcerer_bot.shared.TokenUsage
+	(*CachedContents)(nil),              // 10: codesourcerer_bot.shared.CachedContents
 }
 var file_gen_ai_proto_depIdxs = []int32{
-	0, // 0: codesourcerer_bot.genai.Configuration.configuration:type_name -> codesourcerer_bot.genai.BasicConfig
-	5, // 1: codesourcerer_bot.genai.Configuration.extras:type_name -> codesourcerer_bot.genai.Configuration.ExtrasEntry
-	1, // 2: codesourcerer_bot.genai.GithubContextRequest.config:type_name -> codesourcerer_bot.genai.Configuration
-	6, // 3: codesourcerer_bot.genai.GithubContextRequest.files:type_name -> codesourcerer_bot.shared.SourceFilePayload
-	7, // 4: codesourcerer_bot.genai.GeneratedTestsResponse.tests:type_name -> codesourcerer_bot.shared.TestFilePayload
-	8, // 5: codesourcerer_bot.genai.RetryMechanismPayload.cache:type_name -> codesourcerer_bot.shared.CachedContents
-	2, // 6: codesourcerer_bot.genai.GenAiService.GenerateTestFiles:input_type -> codesourcerer_bot.genai.GithubContextRequest
-	4, // 7: codesourcerer_bot.genai.GenAiService.GenerateRetriedTestFiles:input_type -> codesourcerer_bot.genai.RetryMechanismPayload
-	3, // 8: codesourcerer_bot.genai.GenAiService.GenerateTestFiles:output_type -> codesourcerer_bot.genai.GeneratedTestsResponse
-	3, // 9: codesourcerer_bot.genai.GenAiService.GenerateRetriedTestFiles:output_type -> codesourcerer_bot.genai.GeneratedTestsResponse
-	8, // [8:10] is the sub-list for method output_type
-	6, // [6:8] is the sub-list for method input_type
-	6, // [6:6] is the sub-list for extension type_name
-	6, // [6:6] is the sub-list for extension extendee
-	0, // [0:6] is the sub-list for field type_name
+	5,  // 0: codesourcerer_bot.genai.GithubContextRequest.config:type_name -> codesourcerer_bot.shared.Configuration
+	6,  // 1: codesourcerer_bot.genai.GithubContextRequest.files:type_name -> codesourcerer_bot.shared.SourceFilePayload
+	7,  // 2: codesourcerer_bot.genai.GithubContextRequest.dependencies:type_name -> codesourcerer_bot.shared.SourceFileDependencyPayload
+	8,  // 3: codesourcerer_bot.genai.GeneratedTestsResponse.tests:type_name -> codesourcerer_bot.shared.TestFilePayload
+	1,  // 4: codesourcerer_bot.genai.GeneratedTestsResponse.skipped_files:type_name -> codesourcerer_bot.genai.SkippedFile
+	9,  // 5: codesourcerer_bot.genai.GeneratedTestsResponse.usage:type_name -> codesourcerer_bot.shared.TokenUsage
+	8,  // 6: codesourcerer_bot.genai.TestFileResult.test:type_name -> codesourcerer_bot.shared.TestFilePayload
+	9,  // 7: codesourcerer_bot.genai.TestFileResult.usage:type_name -> codesourcerer_bot.shared.TokenUsage
+	10, // 8: codesourcerer_bot.genai.RetryMechanismPayload.cache:type_name -> codesourcerer_bot.shared.CachedContents
+	0,  // 9: codesourcerer_bot.genai.GenAiService.GenerateTestFiles:input_type -> codesourcerer_bot.genai.GithubContextRequest
+	4,  // 10: codesourcerer_bot.genai.GenAiService.GenerateRetriedTestFiles:input_type -> codesourcerer_bot.genai.RetryMechanismPayload
+	0,  // 11: codesourcerer_bot.genai.GenAiService.StreamTestFiles:input_type -> codesourcerer_bot.genai.GithubContextRequest
+	2,  // 12: codesourcerer_bot.genai.GenAiService.GenerateTestFiles:output_type -> codesourcerer_bot.genai.GeneratedTestsResponse
+	2,  // 13: codesourcerer_bot.genai.GenAiService.GenerateRetriedTestFiles:output_type -> codesourcerer_bot.genai.GeneratedTestsResponse
+	3,  // 14: codesourcerer_bot.genai.GenAiService.StreamTestFiles:output_type -> codesourcerer_bot.genai.TestFileResult
+	12, // [12:15] is the sub-list for method output_type
+	9,  // [9:12] is the sub-list for method input_type
+	9,  // [9:9] is the sub-list for extension type_name
+	9,  // [9:9] is the sub-list for extension extendee
+	0,  // [0:9] is the sub-list for field type_name
 }
 
 func init() { file_gen_ai_proto_init() }
@@ -431,13 +545,17 @@ func file_gen_ai_proto_init() {
 		return
 	}
 	file_shared_proto_init()
+	file_gen_ai_proto_msgTypes[3].OneofWrappers = []any{
+		(*TestFileResult_Test)(nil),
+		(*TestFileResult_Error)(nil),
+	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_gen_ai_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   6,
+			NumMessages:   5,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
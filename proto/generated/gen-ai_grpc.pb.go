@@ -21,6 +21,7 @@ const _ = grpc.SupportPackageIsVersion9
 const (
 	GenAiService_GenerateTestFiles_FullMethodName        = "/codesourcerer_bot.genai.GenAiService/GenerateTestFiles"
 	GenAiService_GenerateRetriedTestFiles_FullMethodName = "/codesourcerer_bot.genai.GenAiService/GenerateRetriedTestFiles"
+	GenAiService_StreamTestFiles_FullMethodName          = "/codesourcerer_bot.genai.GenAiService/StreamTestFiles"
 )
 
 // GenAiServiceClient is the client API for GenAiService service.
@@ -29,6 +30,7 @@ const (
 type GenAiServiceClient interface {
 	GenerateTestFiles(ctx context.Context, in *GithubContextRequest, opts ...grpc.CallOption) (*GeneratedTestsResponse, error)
 	GenerateRetriedTestFiles(ctx context.Context, in *RetryMechanismPayload, opts ...grpc.CallOption) (*GeneratedTestsResponse, error)
+	StreamTestFiles(ctx context.Context, in *GithubContextRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[TestFileResult], error)
 }
 
 type genAiServiceClient struct {
@@ -59,12 +61,32 @@ func (c *genAiServiceClient) GenerateRetriedTestFiles(ctx context.Context, in *R
 	return out, nil
 }
 
+func (c *genAiServiceClient) StreamTestFiles(ctx context.Context, in *GithubContextRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[TestFileResult], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &GenAiService_ServiceDesc.Streams[0], GenAiService_StreamTestFiles_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[GithubContextRequest, TestFileResult]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type GenAiService_StreamTestFilesClient = grpc.ServerStreamingClient[TestFileResult]
+
 // GenAiServiceServer is the server API for GenAiService service.
 // All implementations must embed UnimplementedGenAiServiceServer
 // for forward compatibility.
 type GenAiServiceServer interface {
 	GenerateTestFiles(context.Context, *GithubContextRequest) (*GeneratedTestsResponse, error)
 	GenerateRetriedTestFiles(context.Context, *RetryMechanismPayload) (*GeneratedTestsResponse, error)
+	StreamTestFiles(*GithubContextRequest, grpc.ServerStreamingServer[TestFileResult]) error
 	mustEmbedUnimplementedGenAiServiceServer()
 }
 
@@ -81,6 +103,9 @@ func (UnimplementedGenAiServiceServer) GenerateTestFiles(context.Context, *Githu
 func (UnimplementedGenAiServiceServer) GenerateRetriedTestFiles(context.Context, *RetryMechanismPayload) (*GeneratedTestsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GenerateRetriedTestFiles not implemented")
 }
+func (UnimplementedGenAiServiceServer) StreamTestFiles(*GithubContextRequest, grpc.ServerStreamingServer[TestFileResult]) error {
+	return status.Errorf(codes.Unimplemented, "method StreamTestFiles not implemented")
+}
 func (UnimplementedGenAiServiceServer) mustEmbedUnimplementedGenAiServiceServer() {}
 func (UnimplementedGenAiServiceServer) testEmbeddedByValue()                      {}
 
@@ -138,6 +163,17 @@ func _GenAiService_GenerateRetriedTestFiles_Handler(srv interface{}, ctx context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _GenAiService_StreamTestFiles_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GithubContextRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(GenAiServiceServer).StreamTestFiles(m, &grpc.GenericServerStream[GithubContextRequest, TestFileResult]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type GenAiService_StreamTestFilesServer = grpc.ServerStreamingServer[TestFileResult]
+
 // GenAiService_ServiceDesc is the grpc.ServiceDesc for GenAiService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -154,6 +190,12 @@ var GenAiService_ServiceDesc = grpc.ServiceDesc{
 			Handler:    _GenAiService_GenerateRetriedTestFiles_Handler,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamTestFiles",
+			Handler:       _GenAiService_StreamTestFiles_Handler,
+			ServerStreams: true,
+		},
+	},
 	Metadata: "gen-ai.proto",
 }
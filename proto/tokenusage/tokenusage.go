@@ -0,0 +1,32 @@
+// Package tokenusage holds the token usage helpers shared by the services
+package tokenusage
+
+import pb "github.com/codesourcerer-bot/proto/generated"
+
+// Merge adds the totals of more to usage, matching them by purpose and model
+func Merge(usage []*pb.TokenUsage, more []*pb.TokenUsage) []*pb.TokenUsage {
+	for _, u := range more {
+		merged := false
+		for _, existing := range usage {
+			if existing.GetPurpose() == u.GetPurpose() && existing.GetModel() == u.GetModel() {
+				existing.PromptTokens += u.GetPromptTokens()
+				existing.CompletionTokens += u.GetCompletionTokens()
+				existing.TotalTokens += u.GetTotalTokens()
+				existing.Calls += u.GetCalls()
+				merged = true
+				break
+			}
+		}
+		if !merged {
+			usage = append(usage, &pb.TokenUsage{
+				Purpose:          u.GetPurpose(),
+				Model:            u.GetModel(),
+				PromptTokens:     u.GetPromptTokens(),
+				CompletionTokens: u.GetCompletionTokens(),
+				TotalTokens:      u.GetTotalTokens(),
+				Calls:            u.GetCalls(),
+			})
+		}
+	}
+	return usage
+}
@@ -0,0 +1,54 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/codesourcerer-bot/database/resolvers"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+)
+
+const errorDomain = "database.codesourcerer"
+
+// toStatus converts a database error into a gRPC status, so callers can tell
+// a missing key from an unreachable database
+func toStatus(err error, key string) error {
+	if err == nil {
+		return nil
+	}
+
+	code, reason := codes.Internal, "CACHE_CORRUPTED"
+	switch {
+	case errors.Is(err, resolvers.ErrNotFound):
+		code, reason = codes.NotFound, "CACHE_MISS"
+	case errors.Is(err, resolvers.ErrUnavailable):
+		code, reason = codes.Unavailable, "DATABASE_UNAVAILABLE"
+	}
+
+	return withDetails(status.New(code, err.Error()), &errdetails.ErrorInfo{
+		Reason:   reason,
+		Domain:   errorDomain,
+		Metadata: map[string]string{"key": key},
+	})
+}
+
+// invalidArgument reports a request field the caller has to fix
+func invalidArgument(field, description string) error {
+	return withDetails(status.New(codes.InvalidArgument, fmt.Sprintf("invalid %s: %s", field, description)), &errdetails.BadRequest{
+		FieldViolations: []*errdetails.BadRequest_FieldViolation{{Field: field, Description: description}},
+	})
+}
+
+func withDetails(st *status.Status, detail protoadapt.MessageV1) error {
+	detailed, err := st.WithDetails(detail)
+	if err != nil {
+		log.Printf("Unable to attach error details: %v", err)
+		return st.Err()
+	}
+	return detailed.Err()
+}
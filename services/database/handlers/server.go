@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"context"
+	"time"
 
 	"github.com/codesourcerer-bot/database/resolvers"
 	pb "github.com/codesourcerer-bot/proto/generated"
@@ -21,17 +22,73 @@ func GetGrpcServer(db resolvers.Database) *grpc.Server {
 }
 
 func (s *server) Set(_ context.Context, payload *pb.KeyValType) (*pb.ResultType, error) {
-	return setContextAndTests(s.db, payload.Key, payload.GetValue())
+	if payload.GetKey() == "" {
+		return nil, invalidArgument("key", "the key must not be empty")
+	}
+	if payload.GetValue() == nil {
+		return nil, invalidArgument("value", "the value to cache is required")
+	}
+
+	res, err := setContextAndTests(s.db, payload.Key, payload.GetValue())
+	return res, toStatus(err, payload.Key)
 }
 
 func (s *server) Get(_ context.Context, payload *pb.KeyType) (*pb.CachedContents, error) {
-	return getContextAndTests(s.db, payload.Key)
+	if payload.GetKey() == "" {
+		return nil, invalidArgument("key", "the key must not be empty")
+	}
+
+	res, err := getContextAndTests(s.db, payload.Key)
+	return res, toStatus(err, payload.Key)
 }
 
 func (s *server) Delete(_ context.Context, payload *pb.KeyType) (*pb.ResultType, error) {
-	return deleteContextAndTests(s.db, payload.Key)
+	if payload.GetKey() == "" {
+		return nil, invalidArgument("key", "the key must not be empty")
+	}
+
+	res, err := deleteContextAndTests(s.db, payload.Key)
+	return res, toStatus(err, payload.Key)
 }
 
 func (s *server) IsRetriesExhauted(_ context.Context, payload *pb.KeyType) (*pb.ResultType, error) {
-	return isRetriesExhauted(s.db, payload.Key)
+	if payload.GetKey() == "" {
+		return nil, invalidArgument("key", "the key must not be empty")
+	}
+
+	res, err := isRetriesExhauted(s.db, payload.Key)
+	return res, toStatus(err, payload.Key)
+}
+
+func (s *server) RecordUsage(_ context.Context, payload *pb.UsageRecord) (*pb.ResultType, error) {
+	if payload.GetMergeId() == "" {
+		return nil, invalidArgument("merge_id", "the merge id must not be empty")
+	}
+	if payload.GetRepository() == "" {
+		return nil, invalidArgument("repository", "the repository must not be empty")
+	}
+
+	res, err := recordUsage(s.db, payload)
+	return res, toStatus(err, mergeUsageKey(payload.MergeId))
+}
+
+func (s *server) GetMergeUsage(_ context.Context, payload *pb.KeyType) (*pb.UsageReport, error) {
+	if payload.GetKey() == "" {
+		return nil, invalidArgument("key", "the merge id must not be empty")
+	}
+
+	res, err := getMergeUsage(s.db, payload.Key)
+	return res, toStatus(err, mergeUsageKey(payload.Key))
+}
+
+func (s *server) GetMonthlyUsage(_ context.Context, payload *pb.MonthlyUsageRequest) (*pb.UsageReport, error) {
+	if payload.GetRepository() == "" {
+		return nil, invalidArgument("repository", "the repository must not be empty")
+	}
+	if _, err := time.Parse("2006-01", payload.GetMonth()); err != nil {
+		return nil, invalidArgument("month", "the month must be formatted as YYYY-MM")
+	}
+
+	res, err := getMonthlyUsage(s.db, payload.Repository, payload.Month)
+	return res, toStatus(err, monthlyUsageKey(payload.Repository, payload.Month))
 }
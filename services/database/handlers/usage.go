@@ -0,0 +1,139 @@
+package handlers
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/codesourcerer-bot/database/resolvers"
+	pb "github.com/codesourcerer-bot/proto/generated"
+)
+
+// Usage is kept in hashes whose fields are purpose|model|counter, so that a
+// report can be rebuilt from a single HGETALL
+const usageSeparator = "|"
+
+var usageCounters = []string{"prompt_tokens", "completion_tokens", "total_tokens", "calls"}
+
+func mergeUsageKey(mergeID string) string {
+	return "usage/merge/" + mergeID
+}
+
+func monthlyUsageKey(repository, month string) string {
+	return fmt.Sprintf("usage/repo/%s/%s", repository, month)
+}
+
+// recordUsage adds the usage to the totals of the merge and to the totals of
+// the repository for the current month
+func recordUsage(db resolvers.Database, record *pb.UsageRecord) (*pb.ResultType, error) {
+	fields := make(map[string]int64)
+	for _, u := range record.GetUsage() {
+		prefix := u.GetPurpose() + usageSeparator + u.GetModel() + usageSeparator
+		fields[prefix+"prompt_tokens"] += u.GetPromptTokens()
+		fields[prefix+"completion_tokens"] += u.GetCompletionTokens()
+		fields[prefix+"total_tokens"] += u.GetTotalTokens()
+		fields[prefix+"calls"] += u.GetCalls()
+	}
+
+	if err := db.IncrementFields(mergeUsageKey(record.GetMergeId()), fields); err != nil {
+		return nil, err
+	}
+
+	monthly := monthlyUsageKey(record.GetRepository(), time.Now().UTC().Format("2006-01"))
+	if err := db.IncrementFields(monthly, fields); err != nil {
+		return nil, err
+	}
+	if _, err := db.AddMember(monthly+"/merges", record.GetMergeId()); err != nil {
+		return nil, err
+	}
+
+	return &pb.ResultType{Result: true}, nil
+}
+
+func getMergeUsage(db resolvers.Database, mergeID string) (*pb.UsageReport, error) {
+	fields, err := db.GetFields(mergeUsageKey(mergeID))
+	if err != nil {
+		return nil, err
+	}
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("%w: no usage for %s", resolvers.ErrNotFound, mergeID)
+	}
+
+	usage, err := usageFromFields(fields)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.UsageReport{Usage: usage, Merges: 1}, nil
+}
+
+// getMonthlyUsage reports the usage of a repository in a month. A month
+// without any generation is an empty report rather than an error.
+func getMonthlyUsage(db resolvers.Database, repository, month string) (*pb.UsageReport, error) {
+	key := monthlyUsageKey(repository, month)
+
+	fields, err := db.GetFields(key)
+	if err != nil {
+		return nil, err
+	}
+
+	merges, err := db.CountMembers(key + "/merges")
+	if err != nil {
+		return nil, err
+	}
+
+	usage, err := usageFromFields(fields)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.UsageReport{Usage: usage, Merges: merges}, nil
+}
+
+// usageFromFields rebuilds the totals from the hash fields, sorted by purpose then model
+func usageFromFields(fields map[string]string) ([]*pb.TokenUsage, error) {
+	byKey := make(map[string]*pb.TokenUsage)
+
+	for field, value := range fields {
+		cut := strings.LastIndex(field, usageSeparator)
+		first := strings.Index(field, usageSeparator)
+		if cut < 0 || first == cut {
+			return nil, fmt.Errorf("malformed usage field %q", field)
+		}
+
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("malformed usage value %q for %q", value, field)
+		}
+
+		key := field[:cut]
+		u, ok := byKey[key]
+		if !ok {
+			u = &pb.TokenUsage{Purpose: field[:first], Model: field[first+1 : cut]}
+			byKey[key] = u
+		}
+
+		switch field[cut+1:] {
+		case "prompt_tokens":
+			u.PromptTokens = n
+		case "completion_tokens":
+			u.CompletionTokens = n
+		case "total_tokens":
+			u.TotalTokens = n
+		case "calls":
+			u.Calls = n
+		}
+	}
+
+	usage := make([]*pb.TokenUsage, 0, len(byKey))
+	for _, u := range byKey {
+		usage = append(usage, u)
+	}
+	sort.Slice(usage, func(i, j int) bool {
+		if usage[i].Purpose != usage[j].Purpose {
+			return usage[i].Purpose < usage[j].Purpose
+		}
+		return usage[i].Model < usage[j].Model
+	})
+	return usage, nil
+}
@@ -1,13 +1,30 @@
 package resolvers
 
 import (
+	"errors"
 	"os"
 )
 
+var (
+	// ErrNotFound is returned when the key does not exist
+	ErrNotFound = errors.New("key not found")
+	// ErrUnavailable is returned when the database cannot be reached
+	ErrUnavailable = errors.New("database unavailable")
+)
+
 type Database interface {
 	Set(key string, val string) (bool, error)
 	Get(key string) (string, error)
 	Delete(key string) (bool, error)
+
+	// IncrementFields adds the values to the integer fields of the hash at key in one transaction
+	IncrementFields(key string, fields map[string]int64) error
+	// GetFields returns every field of the hash at key, empty when it does not exist
+	GetFields(key string) (map[string]string, error)
+	// AddMember adds member to the set at key and reports whether it was new
+	AddMember(key, member string) (bool, error)
+	// CountMembers returns the size of the set at key
+	CountMembers(key string) (int64, error)
 }
 
 func Factory() (Database, error) {
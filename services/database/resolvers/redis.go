@@ -12,14 +12,16 @@ type redisDatabase struct {
 
 func (r *redisDatabase) Set(key string, value string) (bool, error) {
 	if _, err := r.client.Set(key, value, 0).Result(); err != nil {
-		return false, fmt.Errorf("unable to set value: %v", err)
+		return false, fmt.Errorf("%w: unable to set value: %v", ErrUnavailable, err)
 	}
 	return true, nil
 }
 
 func (r *redisDatabase) Get(key string) (string, error) {
-	if value, err := r.client.Get(key).Result(); err != nil {
-		return "", fmt.Errorf("unable to get value: %v", err)
+	if value, err := r.client.Get(key).Result(); err == redis.Nil {
+		return "", fmt.Errorf("%w: %s", ErrNotFound, key)
+	} else if err != nil {
+		return "", fmt.Errorf("%w: unable to get value: %v", ErrUnavailable, err)
 	} else {
 		return value, nil
 	}
@@ -27,7 +29,44 @@ func (r *redisDatabase) Get(key string) (string, error) {
 
 func (r *redisDatabase) Delete(key string) (bool, error) {
 	if _, err := r.client.Del(key).Result(); err != nil {
-		return false, fmt.Errorf("unable to delete key: %v", err)
+		return false, fmt.Errorf("%w: unable to delete key: %v", ErrUnavailable, err)
 	}
 	return true, nil
 }
+
+func (r *redisDatabase) IncrementFields(key string, fields map[string]int64) error {
+	_, err := r.client.TxPipelined(func(pipe redis.Pipeliner) error {
+		for field, value := range fields {
+			pipe.HIncrBy(key, field, value)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("%w: unable to increment fields: %v", ErrUnavailable, err)
+	}
+	return nil
+}
+
+func (r *redisDatabase) GetFields(key string) (map[string]string, error) {
+	fields, err := r.client.HGetAll(key).Result()
+	if err != nil {
+		return nil, fmt.Errorf("%w: unable to get fields: %v", ErrUnavailable, err)
+	}
+	return fields, nil
+}
+
+func (r *redisDatabase) AddMember(key, member string) (bool, error) {
+	added, err := r.client.SAdd(key, member).Result()
+	if err != nil {
+		return false, fmt.Errorf("%w: unable to add member: %v", ErrUnavailable, err)
+	}
+	return added > 0, nil
+}
+
+func (r *redisDatabase) CountMembers(key string) (int64, error) {
+	count, err := r.client.SCard(key).Result()
+	if err != nil {
+		return 0, fmt.Errorf("%w: unable to count members: %v", ErrUnavailable, err)
+	}
+	return count, nil
+}
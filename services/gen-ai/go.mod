@@ -4,6 +4,7 @@ go 1.23.1
 
 require (
 	github.com/google/generative-ai-go v0.19.0
+	github.com/smacker/go-tree-sitter v0.0.0-20240827094217-dd81d9e9be82
 	google.golang.org/api v0.216.0
 	google.golang.org/grpc v1.69.2
 )
@@ -0,0 +1,240 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+
+	"github.com/codesourcerer-bot/gen-ai/postprocess"
+	"github.com/codesourcerer-bot/gen-ai/prompts"
+	"github.com/codesourcerer-bot/gen-ai/providers"
+	pb "github.com/codesourcerer-bot/proto/generated"
+	"github.com/codesourcerer-bot/proto/tokenusage"
+)
+
+// batch is a subset of the files that fits the token budget, along with the
+// shared dependencies they reference
+type batch struct {
+	files        []*pb.SourceFilePayload
+	dependencies map[string]bool
+	tokens       int32
+}
+
+// tokenCounter counts the tokens of payload pieces, estimating them when the provider cannot
+type tokenCounter struct {
+	ctx   context.Context
+	model *providers.Model
+}
+
+func (c *tokenCounter) count(piece interface{}) int32 {
+	data, err := json.Marshal(piece)
+	if err != nil {
+		return 0
+	}
+
+	tokens, err := c.model.CountText(c.ctx, string(data))
+	if err != nil {
+		log.Printf("Unable to count tokens, estimating them: %v", err)
+		return int32((len(data) + 3) / 4)
+	}
+	return tokens
+}
+
+// planBatches splits the files of the payload into batches fitting the budget.
+// Files that cannot fit even alone are skipped.
+func planBatches(ctx context.Context, model *providers.Model, history []providers.Message, payload *pb.GithubContextRequest, budget int32) ([]*batch, []*pb.SkippedFile) {
+	counter := &tokenCounter{ctx: ctx, model: model}
+
+	base := &pb.GithubContextRequest{MergeId: payload.GetMergeId(), Context: payload.GetContext(), Config: payload.GetConfig()}
+	baseBytes, _ := json.Marshal(base)
+	overhead, err := model.CountTokens(ctx, history, string(baseBytes))
+	if err != nil {
+		log.Printf("Unable to count tokens, estimating them: %v", err)
+		overhead = int32((len(baseBytes) + len(model.SystemInstruction) + 3) / 4)
+	}
+	available := budget - overhead
+
+	dependencyTokens := make(map[string]int32)
+	for _, dependency := range payload.GetDependencies() {
+		dependencyTokens[dependency.GetName()] = counter.count(dependency)
+	}
+
+	var batches []*batch
+	var skipped []*pb.SkippedFile
+	var current *batch
+
+	for _, f := range payload.GetFiles() {
+		fileTokens := counter.count(f)
+
+		alone := fileTokens
+		for _, name := range sharedDependencies(f) {
+			alone += dependencyTokens[name]
+		}
+
+		if alone > available {
+			skipped = append(skipped, &pb.SkippedFile{
+				Path:   f.GetPath(),
+				Reason: fmt.Sprintf("needs %d tokens with its dependencies, the budget leaves %d", alone, available),
+			})
+			continue
+		}
+
+		if current != nil && current.tokens+addedTokens(current, f, fileTokens, dependencyTokens) > available {
+			current = nil
+		}
+		if current == nil {
+			current = &batch{dependencies: make(map[string]bool)}
+			batches = append(batches, current)
+		}
+
+		current.tokens += addedTokens(current, f, fileTokens, dependencyTokens)
+		current.files = append(current.files, f)
+		for _, name := range sharedDependencies(f) {
+			current.dependencies[name] = true
+		}
+	}
+
+	return batches, skipped
+}
+
+// sharedDependencies lists the dependencies of the file sent once at the top level
+func sharedDependencies(f *pb.SourceFilePayload) []string {
+	var names []string
+	for _, dependency := range f.GetDependencies() {
+		if dependency.GetContent() == "" {
+			names = append(names, dependency.GetName())
+		}
+	}
+	return names
+}
+
+// addedTokens is what adding the file costs the batch, counting only the shared dependencies it does not hold yet
+func addedTokens(b *batch, f *pb.SourceFilePayload, fileTokens int32, dependencyTokens map[string]int32) int32 {
+	tokens := fileTokens
+	for _, name := range sharedDependencies(f) {
+		if !b.dependencies[name] {
+			tokens += dependencyTokens[name]
+		}
+	}
+	return tokens
+}
+
+// request builds the payload of the batch from the original payload
+func (b *batch) request(payload *pb.GithubContextRequest) *pb.GithubContextRequest {
+	req := &pb.GithubContextRequest{
+		MergeId: payload.GetMergeId(),
+		Context: payload.GetContext(),
+		Config:  payload.GetConfig(),
+		Files:   b.files,
+	}
+	for _, dependency := range payload.GetDependencies() {
+		if b.dependencies[dependency.GetName()] {
+			req.Dependencies = append(req.Dependencies, dependency)
+		}
+	}
+	return req
+}
+
+// runBatches generates the batches with at most workers running at once and
+// calls done for each batch as soon as it completes. Calls to done are
+// serialized and receive the token usage of the batch, failed or not.
+func runBatches(ctx context.Context, chain []*providers.Model, prompt *prompts.Prompt, payload *pb.GithubContextRequest, batches []*batch, workers int, done func(i int, res *pb.GeneratedTestsResponse, usage []*pb.TokenUsage, err error)) {
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	sem := make(chan struct{}, workers)
+
+	for i, b := range batches {
+		wg.Add(1)
+		go func(i int, b *batch) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			ledger := providers.NewUsageLedger()
+			recorded := withUsage(chain, ledger, prompts.Generate)
+
+			var res *pb.GeneratedTestsResponse
+			payloadBytes, err := json.Marshal(b.request(payload))
+			if err != nil {
+				err = fmt.Errorf("error serializing payload: %v", err)
+			} else {
+				res, err = generateTestsWithFallback(ctx, recorded, prompt.Examples, string(payloadBytes))
+			}
+			if err == nil {
+				res.PromptVersion = prompt.Version
+				postprocess.Apply(res.Tests, payload.GetConfig())
+				repairSyntax(ctx, recorded, prompt.Examples, []string{string(payloadBytes)}, res, payload.GetConfig())
+			}
+
+			if err != nil {
+				log.Printf("Batch %d of %d failed: %v", i+1, len(batches), err)
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			done(i, res, usageProtos(ledger), err)
+		}(i, b)
+	}
+
+	wg.Wait()
+}
+
+// generateBatches generates the batches and merges their tests in order.
+// The files of a failed batch are reported as skipped. When every batch
+// fails, the merged response is returned along with the error for its usage.
+func generateBatches(ctx context.Context, chain []*providers.Model, prompt *prompts.Prompt, payload *pb.GithubContextRequest, batches []*batch, workers int) (*pb.GeneratedTestsResponse, error) {
+	results := make([]*pb.GeneratedTestsResponse, len(batches))
+	errs := make([]error, len(batches))
+	usages := make([][]*pb.TokenUsage, len(batches))
+
+	runBatches(ctx, chain, prompt, payload, batches, workers, func(i int, res *pb.GeneratedTestsResponse, usage []*pb.TokenUsage, err error) {
+		results[i], errs[i] = res, err
+		usages[i] = usage
+	})
+
+	merged := &pb.GeneratedTestsResponse{PromptVersion: prompt.Version}
+	var firstErr error
+
+	for i, res := range results {
+		merged.Usage = tokenusage.Merge(merged.Usage, usages[i])
+
+		if errs[i] != nil {
+			if firstErr == nil {
+				firstErr = errs[i]
+			}
+			for _, f := range batches[i].files {
+				merged.SkippedFiles = append(merged.SkippedFiles, &pb.SkippedFile{Path: f.GetPath(), Reason: fmt.Sprintf("generation failed: %v", errs[i])})
+			}
+			continue
+		}
+
+		merged.Tests = append(merged.Tests, res.GetTests()...)
+		merged.SkippedFiles = append(merged.SkippedFiles, res.GetSkippedFiles()...)
+		merged.Model = joinModels(merged.Model, res.GetModel())
+	}
+
+	if len(merged.Tests) == 0 && firstErr != nil {
+		return merged, firstErr
+	}
+
+	return merged, nil
+}
+
+// joinModels adds model to the comma-separated list of models that produced tests
+func joinModels(models, model string) string {
+	if model == "" {
+		return models
+	}
+	for _, m := range strings.Split(models, ", ") {
+		if m == model {
+			return models
+		}
+	}
+	if models == "" {
+		return model
+	}
+	return models + ", " + model
+}
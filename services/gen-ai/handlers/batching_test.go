@@ -0,0 +1,97 @@
+package handlers
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/codesourcerer-bot/gen-ai/providers"
+	pb "github.com/codesourcerer-bot/proto/generated"
+)
+
+// sourceFile is a file of roughly tokens tokens for the fake provider, which
+// counts four characters as a token
+func sourceFile(path string, tokens int, shared ...string) *pb.SourceFilePayload {
+	f := &pb.SourceFilePayload{Path: path, Content: strings.Repeat("x", tokens*4)}
+	for _, name := range shared {
+		f.Dependencies = append(f.Dependencies, &pb.SourceFileDependencyPayload{Name: name})
+	}
+	return f
+}
+
+func TestPlanBatches(t *testing.T) {
+	tests := []struct {
+		name         string
+		files        []*pb.SourceFilePayload
+		dependencies []*pb.SourceFileDependencyPayload
+		budget       int32
+		batches      [][]string
+		skipped      []string
+	}{
+		{
+			name:    "files fitting together share a batch",
+			files:   []*pb.SourceFilePayload{sourceFile("a.py", 500), sourceFile("b.py", 500)},
+			budget:  2000,
+			batches: [][]string{{"a.py", "b.py"}},
+		},
+		{
+			name:    "files over the budget together are split",
+			files:   []*pb.SourceFilePayload{sourceFile("a.py", 500), sourceFile("b.py", 500), sourceFile("c.py", 500)},
+			budget:  1200,
+			batches: [][]string{{"a.py", "b.py"}, {"c.py"}},
+		},
+		{
+			name:    "a file over the budget alone is skipped",
+			files:   []*pb.SourceFilePayload{sourceFile("a.py", 500), sourceFile("big.py", 2000)},
+			budget:  1200,
+			batches: [][]string{{"a.py"}},
+			skipped: []string{"big.py"},
+		},
+		{
+			name:         "a shared dependency is counted once per batch",
+			files:        []*pb.SourceFilePayload{sourceFile("a.py", 400, "lib.py"), sourceFile("b.py", 400, "lib.py")},
+			dependencies: []*pb.SourceFileDependencyPayload{{Name: "lib.py", Content: strings.Repeat("x", 1600)}},
+			budget:       1400,
+			batches:      [][]string{{"a.py", "b.py"}},
+		},
+		{
+			name:         "a shared dependency counts towards a file alone",
+			files:        []*pb.SourceFilePayload{sourceFile("a.py", 400, "lib.py"), sourceFile("b.py", 400)},
+			dependencies: []*pb.SourceFileDependencyPayload{{Name: "lib.py", Content: strings.Repeat("x", 4000)}},
+			budget:       1200,
+			batches:      [][]string{{"b.py"}},
+			skipped:      []string{"a.py"},
+		},
+	}
+
+	model := &providers.Model{Provider: providers.NewFakeProvider(), Name: "fake"}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			payload := &pb.GithubContextRequest{MergeId: "merge_1", Files: tt.files, Dependencies: tt.dependencies}
+
+			batches, skipped := planBatches(context.Background(), model, nil, payload, tt.budget)
+
+			var gotBatches [][]string
+			for _, b := range batches {
+				var paths []string
+				for _, f := range b.files {
+					paths = append(paths, f.GetPath())
+				}
+				gotBatches = append(gotBatches, paths)
+			}
+			var gotSkipped []string
+			for _, f := range skipped {
+				gotSkipped = append(gotSkipped, f.GetPath())
+			}
+
+			if !reflect.DeepEqual(gotBatches, tt.batches) {
+				t.Errorf("expected batches %v, got %v", tt.batches, gotBatches)
+			}
+			if !reflect.DeepEqual(gotSkipped, tt.skipped) {
+				t.Errorf("expected skipped files %v, got %v", tt.skipped, gotSkipped)
+			}
+		})
+	}
+}
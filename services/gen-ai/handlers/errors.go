@@ -0,0 +1,94 @@
+package handlers
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/codesourcerer-bot/gen-ai/providers"
+	pb "github.com/codesourcerer-bot/proto/generated"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const errorDomain = "gen-ai.codesourcerer"
+
+// reasons name the failures in the ErrorInfo detail of a status
+var reasons = map[codes.Code]string{
+	codes.ResourceExhausted: "MODEL_QUOTA_EXHAUSTED",
+	codes.DeadlineExceeded:  "MODEL_TIMEOUT",
+	codes.Unavailable:       "MODEL_UNAVAILABLE",
+	codes.Canceled:          "REQUEST_CANCELED",
+	codes.Internal:          "GENERATION_FAILED",
+}
+
+// toStatus converts an error into a gRPC status whose code tells the caller
+// whether to fix the request, retry later or give up. Statuses created by the
+// handlers are returned unchanged, wrapped ones come from the model providers.
+func toStatus(err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := err.(interface{ GRPCStatus() *status.Status }); ok {
+		return err
+	}
+
+	code := providers.StatusCode(err)
+	st := status.New(code, err.Error())
+
+	detailed, detailErr := st.WithDetails(&errdetails.ErrorInfo{Reason: reasons[code], Domain: errorDomain})
+	if detailErr != nil {
+		log.Printf("Unable to attach error details: %v", detailErr)
+		return st.Err()
+	}
+	return detailed.Err()
+}
+
+// toStatusWithUsage converts the error like toStatus and attaches the usage of
+// the calls made before the failure, so that the caller can still record it
+func toStatusWithUsage(err error, usage []*pb.TokenUsage) error {
+	err = toStatus(err)
+	if err == nil || len(usage) == 0 {
+		return err
+	}
+
+	st, _ := status.FromError(err)
+	detailed, detailErr := st.WithDetails(&pb.UsageReport{Usage: usage})
+	if detailErr != nil {
+		log.Printf("Unable to attach the usage to the error: %v", detailErr)
+		return err
+	}
+	return detailed.Err()
+}
+
+// invalidArgument reports a request field the caller has to fix
+func invalidArgument(field, description string) error {
+	st := status.New(codes.InvalidArgument, fmt.Sprintf("invalid %s: %s", field, description))
+
+	detailed, err := st.WithDetails(&errdetails.BadRequest{
+		FieldViolations: []*errdetails.BadRequest_FieldViolation{{Field: field, Description: description}},
+	})
+	if err != nil {
+		log.Printf("Unable to attach error details: %v", err)
+		return st.Err()
+	}
+	return detailed.Err()
+}
+
+func validateContextRequest(payload *pb.GithubContextRequest) error {
+	if len(payload.GetFiles()) == 0 {
+		return invalidArgument("files", "at least one source file is required")
+	}
+	return nil
+}
+
+func validateRetryPayload(payload *pb.RetryMechanismPayload) error {
+	if len(payload.GetCache().GetTests()) == 0 {
+		return invalidArgument("cache.tests", "the cached tests to regenerate are required")
+	}
+	if len(payload.GetLogs()) == 0 {
+		return invalidArgument("logs", "the logs of the failed run are required")
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/codesourcerer-bot/gen-ai/providers"
+	pb "github.com/codesourcerer-bot/proto/generated"
+)
+
+const attemptTimeout = 15 * time.Second
+
+const correctionPrompt = "Your previous answer could not be used: %v. Answer again with only a JSON object of the form {\"tests\": [{\"testname\": ..., \"testfilepath\": ..., \"parentpath\": ..., \"code\": ..., \"cases\": [{\"name\": ..., \"target\": ..., \"category\": \"happy_path\" | \"edge_case\" | \"error\", \"rationale\": ...}]}]}, keeping the test cases you described, without code fences or any other text."
+
+// errInvalidOutput marks a response that could not be parsed into tests
+var errInvalidOutput = errors.New("invalid model output")
+
+// generateTestsWithFallback asks each model of the chain in turn, moving on to
+// the next one on retryable errors and on output that does not parse. The
+// model that produced the tests is recorded on the response.
+func generateTestsWithFallback(ctx context.Context, chain []*providers.Model, history []providers.Message, prompt ...string) (*pb.GeneratedTestsResponse, error) {
+	var lastErr error
+
+	for i, model := range chain {
+		result, err := generateTests(ctx, model, history, prompt)
+		if err == nil {
+			result.Model = fmt.Sprintf("%s:%s", model.Provider.Name(), model.Name)
+			return result, nil
+		}
+
+		lastErr = err
+		if ctx.Err() != nil || !(errors.Is(err, errInvalidOutput) || providers.IsRetryable(err)) {
+			return nil, err
+		}
+
+		if i+1 < len(chain) {
+			log.Printf("Model %s:%s failed, falling back to %s:%s: %v", model.Provider.Name(), model.Name, chain[i+1].Provider.Name(), chain[i+1].Name, err)
+		}
+	}
+
+	return nil, lastErr
+}
+
+// generateTests asks the model once, then once more with the parsing error as a
+// corrective turn when the output is not a valid GeneratedTestsResponse
+func generateTests(ctx context.Context, model *providers.Model, history []providers.Message, prompt []string) (*pb.GeneratedTestsResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, attemptTimeout)
+	defer cancel()
+
+	response, err := model.Generate(ctx, history, prompt...)
+	if err != nil {
+		return nil, fmt.Errorf("error generating response: %w", err)
+	}
+
+	result, err := parseTestsResponse(response.Text)
+	if err == nil {
+		return result, nil
+	}
+
+	log.Printf("Model %s:%s answered with invalid output, asking it to correct it: %v", model.Provider.Name(), model.Name, err)
+
+	corrective := append(append([]providers.Message(nil), history...),
+		providers.Message{Role: providers.RoleUser, Text: strings.Join(prompt, "\n")},
+		providers.Message{Role: providers.RoleModel, Text: response.Text},
+	)
+
+	response, err = model.Generate(ctx, corrective, fmt.Sprintf(correctionPrompt, err))
+	if err != nil {
+		return nil, fmt.Errorf("error generating response: %w", err)
+	}
+
+	return parseTestsResponse(response.Text)
+}
@@ -2,51 +2,41 @@ package handlers
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
-	"fmt"
-	"time"
+	"log"
 
-	"github.com/codesourcerer-bot/gen-ai/contexts"
+	"github.com/codesourcerer-bot/gen-ai/prompts"
+	"github.com/codesourcerer-bot/gen-ai/providers"
 	pb "github.com/codesourcerer-bot/proto/generated"
-	"github.com/google/generative-ai-go/genai"
 )
 
-// TODO: Handle the Configuration Neatly
-func getTestsFromAI(ctx context.Context, payload *pb.GithubContextRequest, model *genai.GenerativeModel) (*pb.GeneratedTestsResponse, error) {
-	session := model.StartChat()
-	session.History = contexts.GeneratorModelContext
-
-	payloadBytes, err := json.Marshal(payload)
-	if err != nil {
-		return nil, fmt.Errorf("error serializing payload: %v", err)
-	}
-
-	ctx, cancel := context.WithTimeout(ctx, 15*time.Second)
-	defer cancel()
-
-	response, err := session.SendMessage(ctx, genai.Text(string(payloadBytes)))
+// getTestsFromAI splits the payload into batches fitting the token budget and
+// generates them concurrently. Files too large for the budget are reported as skipped.
+func getTestsFromAI(ctx context.Context, payload *pb.GithubContextRequest, chain []*providers.Model, budget int32, workers int) (*pb.GeneratedTestsResponse, error) {
+	prompt, chain, err := withPrompt(prompts.Generate, payload.GetConfig(), chain)
 	if err != nil {
-		return nil, fmt.Errorf("error generating response: %v", err)
+		return nil, err
 	}
 
-	if len(response.Candidates) == 0 || len(response.Candidates[0].Content.Parts) == 0 {
-		return nil, errors.New("model did not generate any response")
+	batches, skipped := planBatches(ctx, chain[0], prompt.Examples, payload, budget)
+	for _, f := range skipped {
+		log.Printf("Skipping %s: %s", f.GetPath(), f.GetReason())
 	}
 
-	part := response.Candidates[0].Content.Parts[0]
-
-	textPart, ok := part.(genai.Text)
-	if !ok {
-		return nil, fmt.Errorf("unable to convert to text type: %v", err)
+	if len(batches) == 0 {
+		if len(skipped) == 0 {
+			return nil, errors.New("no files to generate tests for")
+		}
+		return &pb.GeneratedTestsResponse{SkippedFiles: skipped, PromptVersion: prompt.Version}, nil
 	}
 
-	var result pb.GeneratedTestsResponse
-
-	if err := json.Unmarshal([]byte(textPart), &result); err != nil {
-		return nil, fmt.Errorf("Unable to unmarshal: %v", err)
+	res, err := generateBatches(ctx, chain, prompt, payload, batches, workers)
+	if err != nil {
+		// The usage of the failed batches is still reported
+		return res, err
 	}
 
-	return &result, nil
+	res.SkippedFiles = append(skipped, res.SkippedFiles...)
+	return res, nil
 
 }
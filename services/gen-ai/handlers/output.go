@@ -0,0 +1,156 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	pb "github.com/codesourcerer-bot/proto/generated"
+)
+
+var (
+	fenceRegex         = regexp.MustCompile("(?s)```[a-zA-Z]*\\s*\\n?(.*?)```")
+	trailingCommaRegex = regexp.MustCompile(`,(\s*[}\]])`)
+)
+
+// parseTestsResponse extracts the JSON answer from the model output, repairs
+// common breakage and validates it against the GeneratedTestsResponse shape
+func parseTestsResponse(text string) (*pb.GeneratedTestsResponse, error) {
+	raw := repairJSON(extractJSON(text))
+
+	var result pb.GeneratedTestsResponse
+
+	decoder := json.NewDecoder(strings.NewReader(raw))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&result); err != nil {
+		return nil, fmt.Errorf("%w: unable to unmarshal: %v", errInvalidOutput, err)
+	}
+
+	if err := validateTestsResponse(&result); err != nil {
+		return nil, fmt.Errorf("%w: %v", errInvalidOutput, err)
+	}
+
+	return &result, nil
+}
+
+// extractJSON drops code fences and any prose around the outermost JSON object.
+// Valid JSON is kept as is, since the code of a test may itself hold fences.
+func extractJSON(text string) string {
+	if trimmed := strings.TrimSpace(text); json.Valid([]byte(trimmed)) {
+		return trimmed
+	}
+
+	if object := outermostObject(text); json.Valid([]byte(object)) {
+		return object
+	}
+
+	if match := fenceRegex.FindStringSubmatch(text); match != nil {
+		text = match[1]
+	}
+	return outermostObject(text)
+}
+
+// outermostObject cuts text from the first "{" to the last "}"
+func outermostObject(text string) string {
+	start := strings.Index(text, "{")
+	end := strings.LastIndex(text, "}")
+	if start < 0 || end < start {
+		return strings.TrimSpace(text)
+	}
+	return text[start : end+1]
+}
+
+// repairJSON fixes the breakage models commonly produce: raw control
+// characters inside strings and trailing commas before a closing bracket
+func repairJSON(raw string) string {
+	if json.Valid([]byte(raw)) {
+		return raw
+	}
+
+	var repaired bytes.Buffer
+	inString, escaped := false, false
+
+	for _, r := range raw {
+		switch {
+		case escaped:
+			escaped = false
+		case inString && r == '\\':
+			escaped = true
+		case r == '"':
+			inString = !inString
+		case inString && r == '\n':
+			repaired.WriteString(`\n`)
+			continue
+		case inString && r == '\r':
+			repaired.WriteString(`\r`)
+			continue
+		case inString && r == '\t':
+			repaired.WriteString(`\t`)
+			continue
+		}
+		repaired.WriteRune(r)
+	}
+
+	return trailingCommaRegex.ReplaceAllString(repaired.String(), "$1")
+}
+
+// validateTestsResponse checks that every test can be committed
+func validateTestsResponse(res *pb.GeneratedTestsResponse) error {
+	if len(res.GetTests()) == 0 {
+		return errors.New("tests must not be empty")
+	}
+
+	var problems []string
+	for i, test := range res.GetTests() {
+		for field, value := range map[string]string{
+			"testfilepath": test.GetTestfilepath(),
+			"parentpath":   test.GetParentpath(),
+			"code":         test.GetCode(),
+		} {
+			if strings.TrimSpace(value) == "" {
+				problems = append(problems, fmt.Sprintf("tests[%d].%s must not be empty", i, field))
+			}
+		}
+		problems = append(problems, normalizeCases(test, i)...)
+	}
+
+	if len(problems) > 0 {
+		return errors.New(strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// caseCategories maps the spellings models use to the category of a test case
+var caseCategories = map[string]string{
+	"happy_path": "happy_path",
+	"happy":      "happy_path",
+	"edge_case":  "edge_case",
+	"edge":       "edge_case",
+	"error":      "error",
+	"failure":    "error",
+	"exception":  "error",
+}
+
+// normalizeCases brings the categories of the test cases to their canonical
+// spelling, reporting the ones that match no category. Cases are optional, a
+// test without them is committed without a checklist.
+func normalizeCases(test *pb.TestFilePayload, i int) []string {
+	var problems []string
+	for j, c := range test.GetCases() {
+		key := strings.NewReplacer(" ", "_", "-", "_").Replace(strings.ToLower(strings.TrimSpace(c.GetCategory())))
+		category, ok := caseCategories[key]
+		if !ok {
+			problems = append(problems, fmt.Sprintf("tests[%d].cases[%d].category must be happy_path, edge_case or error, got %q", i, j, c.GetCategory()))
+			continue
+		}
+		c.Category = category
+
+		if strings.TrimSpace(c.GetName()) == "" {
+			problems = append(problems, fmt.Sprintf("tests[%d].cases[%d].name must not be empty", i, j))
+		}
+	}
+	return problems
+}
@@ -0,0 +1,48 @@
+package handlers
+
+import "testing"
+
+func TestExtractJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{name: "plain object", text: ` {"tests": []} `, want: `{"tests": []}`},
+		{name: "fenced object", text: "```json\n{\"tests\": []}\n```", want: `{"tests": []}`},
+		{name: "prose around the object", text: "Here are the tests:\n{\"tests\": []}\nGood luck!", want: `{"tests": []}`},
+		{name: "fences inside the code are kept", text: "{\"code\": \"```py\\nx\\n```\"}", want: "{\"code\": \"```py\\nx\\n```\"}"},
+		{name: "broken object inside a fence", text: "```json\n{\"tests\": [1,]}\n```\nDone.", want: `{"tests": [1,]}`},
+		{name: "no object", text: " no JSON here ", want: "no JSON here"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := extractJSON(tt.text); got != tt.want {
+				t.Errorf("extractJSON(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRepairJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  string
+		want string
+	}{
+		{name: "valid JSON is kept", raw: `{"a": [1, 2]}`, want: `{"a": [1, 2]}`},
+		{name: "trailing commas", raw: `{"a": [1, 2,], "b": 3,}`, want: `{"a": [1, 2], "b": 3}`},
+		{name: "raw control characters in strings", raw: "{\"code\": \"def f():\n\treturn 1\r\"}", want: `{"code": "def f():\n\treturn 1\r"}`},
+		{name: "escaped quotes stay inside the string", raw: "{\"code\": \"say \\\"hi\\\"\n\",}", want: `{"code": "say \"hi\"\n"}`},
+		{name: "newlines between values are kept", raw: "{\n\"a\": 1,\n}", want: "{\n\"a\": 1\n}"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := repairJSON(tt.raw); got != tt.want {
+				t.Errorf("repairJSON(%q) = %q, want %q", tt.raw, got, tt.want)
+			}
+		})
+	}
+}
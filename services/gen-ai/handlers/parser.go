@@ -5,34 +5,28 @@ import (
 	"fmt"
 	"time"
 
-	"github.com/codesourcerer-bot/gen-ai/contexts"
-	"github.com/google/generative-ai-go/genai"
+	"github.com/codesourcerer-bot/gen-ai/prompts"
+	"github.com/codesourcerer-bot/gen-ai/providers"
+	pb "github.com/codesourcerer-bot/proto/generated"
 )
 
-func getParsedLogsFromAI(c context.Context, payload []string, model *genai.GenerativeModel) (genai.Part, error) {
-
-	session := model.StartChat()
-	session.History = contexts.ParserModelContext
+// getParsedLogsFromAI summarizes the test logs, rendering the prompt with the
+// framework of the configuration the tests were generated with
+func getParsedLogsFromAI(c context.Context, payload []string, config *pb.Configuration, model *providers.Model) (string, error) {
 
 	c, cancel := context.WithTimeout(c, 15*time.Second)
 	defer cancel()
 
-	logs := make([]genai.Part, len(payload))
-	for _, p := range payload {
-		logs = append(logs, genai.Text(string(p)))
-	}
-
-	response, err := session.SendMessage(c, logs...)
+	prompt, prompted, err := withPrompt(prompts.ParseLogs, config, []*providers.Model{model})
 	if err != nil {
-		return nil, fmt.Errorf("error generating response: %v", err)
+		return "", err
 	}
 
-	if len(response.Candidates) == 0 || len(response.Candidates[0].Content.Parts) == 0 {
-		return nil, fmt.Errorf("model did not generate any response")
+	response, err := prompted[0].Generate(c, prompt.Examples, payload...)
+	if err != nil {
+		return "", fmt.Errorf("error generating response: %w", err)
 	}
 
-	part := response.Candidates[0].Content.Parts[0]
-
-	return part, nil
+	return response.Text, nil
 
 }
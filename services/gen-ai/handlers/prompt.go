@@ -0,0 +1,25 @@
+package handlers
+
+import (
+	"github.com/codesourcerer-bot/gen-ai/prompts"
+	"github.com/codesourcerer-bot/gen-ai/providers"
+	pb "github.com/codesourcerer-bot/proto/generated"
+)
+
+// withPrompt renders the prompt of the purpose and returns copies of the chain
+// carrying its system instruction
+func withPrompt(purpose prompts.Purpose, config *pb.Configuration, chain []*providers.Model) (*prompts.Prompt, []*providers.Model, error) {
+	prompt, err := prompts.Render(purpose, config)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	prompted := make([]*providers.Model, 0, len(chain))
+	for _, model := range chain {
+		copied := *model
+		copied.SystemInstruction = prompt.Instruction
+		prompted = append(prompted, &copied)
+	}
+
+	return prompt, prompted, nil
+}
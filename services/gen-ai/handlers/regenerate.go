@@ -3,44 +3,56 @@ package handlers
 import (
 	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
-	"time"
+	"log"
 
-	"github.com/codesourcerer-bot/gen-ai/contexts"
+	"github.com/codesourcerer-bot/gen-ai/postprocess"
+	"github.com/codesourcerer-bot/gen-ai/prompts"
+	"github.com/codesourcerer-bot/gen-ai/providers"
 	pb "github.com/codesourcerer-bot/proto/generated"
-	"github.com/google/generative-ai-go/genai"
 )
 
-func generateRetriedTestsFromAI(ctx context.Context, parsedLogs genai.Part, cache *pb.CachedContents, model *genai.GenerativeModel) (*pb.GeneratedTestsResponse, error) {
-	session := model.StartChat()
-	session.History = contexts.GetRegeratorContext(cache)
-
-	ctx, cancel := context.WithTimeout(ctx, 15*time.Second)
-	defer cancel()
+// retryRequest is the payload the regenerate prompt describes: the cached
+// sources and tests along with the summary of the failed run
+type retryRequest struct {
+	MergeId      string                            `json:"merge_id"`
+	Framework    string                            `json:"framework,omitempty"`
+	Contexts     []*pb.SourceFilePayload           `json:"contexts"`
+	Tests        []*pb.TestFilePayload             `json:"tests"`
+	Dependencies []*pb.SourceFileDependencyPayload `json:"dependencies,omitempty"`
+	Error        string                            `json:"error"`
+}
 
-	response, err := session.SendMessage(ctx, parsedLogs)
+// generateRetriedTestsFromAI renders the regenerate prompt with the cached
+// configuration. Its framework is empty when the cached tests span several, in
+// which case the default prompt is used.
+func generateRetriedTestsFromAI(ctx context.Context, parsedLogs string, cache *pb.CachedContents, chain []*providers.Model) (*pb.GeneratedTestsResponse, error) {
+	prompt, chain, err := withPrompt(prompts.Regenerate, cache.GetConfig(), chain)
 	if err != nil {
-		return nil, fmt.Errorf("error generating response: %v", err)
+		return nil, err
 	}
 
-	if len(response.Candidates) == 0 || len(response.Candidates[0].Content.Parts) == 0 {
-		return nil, errors.New("model did not generate any response")
-	}
-
-	part := response.Candidates[0].Content.Parts[0]
-
-	textPart, ok := part.(genai.Text)
-	if !ok {
-		return nil, fmt.Errorf("unable to convert to text type: %v", err)
+	request, err := json.Marshal(&retryRequest{
+		MergeId:      cache.GetMergeId(),
+		Framework:    cache.GetConfig().GetConfiguration().GetTestingFramework(),
+		Contexts:     cache.GetContexts(),
+		Tests:        cache.GetTests(),
+		Dependencies: cache.GetDependencies(),
+		Error:        parsedLogs,
+	})
+	if err != nil {
+		log.Printf("Unable to serialize the retry request: %v", err)
+		return nil, fmt.Errorf("unable to serialize the retry request")
 	}
 
-	var result pb.GeneratedTestsResponse
-
-	if err := json.Unmarshal([]byte(textPart), &result); err != nil {
-		return nil, fmt.Errorf("Unable to unmarshal: %v", err)
+	res, err := generateTestsWithFallback(ctx, chain, prompt.Examples, string(request))
+	if err != nil {
+		return nil, err
 	}
 
-	return &result, nil
-
+	// The repository settings, like the watermark, apply to regenerated tests too
+	res.PromptVersion = prompt.Version
+	postprocess.Apply(res.Tests, cache.GetConfig())
+	repairSyntax(ctx, chain, prompt.Examples, []string{string(request)}, res, cache.GetConfig())
+	return res, nil
 }
@@ -2,53 +2,150 @@ package handlers
 
 import (
 	"context"
+	"log"
+	"sync/atomic"
+	"time"
 
 	pb "github.com/codesourcerer-bot/proto/generated"
 
 	"github.com/codesourcerer-bot/gen-ai/models"
+	"github.com/codesourcerer-bot/gen-ai/prompts"
+	"github.com/codesourcerer-bot/gen-ai/providers"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
 )
 
-type server struct {
+// Server serves the GenAI RPCs with models created once and shared across requests
+type Server struct {
 	pb.UnimplementedGenAiServiceServer
+
+	health *health.Server
+	models atomic.Pointer[models.Models]
 }
 
-func GetGrpcServer() *grpc.Server {
+// GetGrpcServer registers the GenAI and health services. The server reports
+// NOT_SERVING until InitializeModels succeeds.
+func GetGrpcServer() (*grpc.Server, *Server) {
+	s := &Server{health: health.NewServer()}
+	s.setServing(healthpb.HealthCheckResponse_NOT_SERVING)
+
 	grpcServer := grpc.NewServer()
-	pb.RegisterGenAiServiceServer(grpcServer, &server{})
-	return grpcServer
+	pb.RegisterGenAiServiceServer(grpcServer, s)
+	healthpb.RegisterHealthServer(grpcServer, s.health)
+	return grpcServer, s
 }
 
-func (s *server) GenerateTestFiles(_ context.Context, payload *pb.GithubContextRequest) (*pb.GeneratedTestsResponse, error) {
+func (s *Server) setServing(servingStatus healthpb.HealthCheckResponse_ServingStatus) {
+	s.health.SetServingStatus("", servingStatus)
+	s.health.SetServingStatus(pb.GenAiService_ServiceDesc.ServiceName, servingStatus)
+}
+
+// InitializeModels creates the models, retrying with backoff until it succeeds or ctx is done
+func (s *Server) InitializeModels(ctx context.Context) {
+	backoff := 5 * time.Second
+
+	for {
+		m, err := models.NewModels(ctx)
+		if err == nil {
+			s.models.Store(m)
+			s.setServing(healthpb.HealthCheckResponse_SERVING)
+			log.Printf("Models initialized with the %s provider", m.Provider.Name())
+			return
+		}
 
-	ctx, client, model := models.InitializeGeneratorModel()
-	defer client.Close()
+		log.Printf("Unable to initialize models, retrying in %v: %v", backoff, err)
 
-	res, err := getTestsFromAI(ctx, payload, model)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+
+		backoff = min(2*backoff, time.Minute)
+	}
+}
+
+// Shutdown stops reporting the server as ready so that no new traffic is routed to it
+func (s *Server) Shutdown() {
+	s.health.Shutdown()
+}
+
+// Close releases the model clients once the server has stopped
+func (s *Server) Close() {
+	if m := s.models.Load(); m != nil {
+		if err := m.Close(); err != nil {
+			log.Printf("Unable to close models: %v", err)
+		}
+	}
+}
+
+func (s *Server) getModels() (*models.Models, error) {
+	m := s.models.Load()
+	if m == nil {
+		return nil, status.Error(codes.Unavailable, "models are not initialized")
+	}
+	return m, nil
+}
+
+func (s *Server) GenerateTestFiles(ctx context.Context, payload *pb.GithubContextRequest) (*pb.GeneratedTestsResponse, error) {
+	if err := validateContextRequest(payload); err != nil {
+		return nil, err
+	}
+
+	m, err := s.getModels()
 	if err != nil {
 		return nil, err
 	}
 
+	res, err := getTestsFromAI(ctx, payload, m.GeneratorChain(payload.GetConfig().GetModel()), m.TokenBudget, m.Workers)
+	if err != nil {
+		return nil, toStatusWithUsage(err, res.GetUsage())
+	}
+
 	return res, nil
 }
 
-func (s *server) GenerateRetriedTestFiles(_ context.Context, payload *pb.RetryMechanismPayload) (*pb.GeneratedTestsResponse, error) {
-	ctx, client, model := models.InitializeParserModel()
-	defer client.Close()
+func (s *Server) StreamTestFiles(payload *pb.GithubContextRequest, stream grpc.ServerStreamingServer[pb.TestFileResult]) error {
+	if err := validateContextRequest(payload); err != nil {
+		return err
+	}
 
-	logsPart, err := getParsedLogsFromAI(ctx, payload.GetLogs(), model)
+	m, err := s.getModels()
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	ctx, client, model = models.InitializeRetryModel()
-	defer client.Close()
+	return toStatus(streamTestsFromAI(stream.Context(), payload, m.GeneratorChain(payload.GetConfig().GetModel()), m.TokenBudget, m.Workers, stream))
+}
+
+func (s *Server) GenerateRetriedTestFiles(ctx context.Context, payload *pb.RetryMechanismPayload) (*pb.GeneratedTestsResponse, error) {
+	if err := validateRetryPayload(payload); err != nil {
+		return nil, err
+	}
 
-	res, err := generateRetriedTestsFromAI(ctx, logsPart, payload.GetCache(), model)
+	m, err := s.getModels()
 	if err != nil {
 		return nil, err
 	}
 
+	ledger := providers.NewUsageLedger()
+
+	// The calls made before a failure are reported with the error
+	parsedLogs, err := getParsedLogsFromAI(ctx, payload.GetLogs(), payload.GetCache().GetConfig(), withUsage([]*providers.Model{m.Parser}, ledger, prompts.ParseLogs)[0])
+	if err != nil {
+		return nil, toStatusWithUsage(err, usageProtos(ledger))
+	}
+
+	res, err := generateRetriedTestsFromAI(ctx, parsedLogs, payload.GetCache(), withUsage(m.RetryChain(payload.GetCache().GetConfig().GetModel()), ledger, prompts.Regenerate))
+	if err != nil {
+		return nil, toStatusWithUsage(err, usageProtos(ledger))
+	}
+
+	res.Usage = usageProtos(ledger)
+
 	return res, nil
 
 }
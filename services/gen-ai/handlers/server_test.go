@@ -0,0 +1,150 @@
+package handlers
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/codesourcerer-bot/gen-ai/models"
+	"github.com/codesourcerer-bot/gen-ai/postprocess"
+	"github.com/codesourcerer-bot/gen-ai/prompts"
+	"github.com/codesourcerer-bot/gen-ai/providers"
+	pb "github.com/codesourcerer-bot/proto/generated"
+)
+
+// The tests replay the model exchanges recorded in testdata/cassettes. After
+// changing a prompt or a request, record them again from this directory with
+//
+//	rm -r testdata/cassettes
+//	LLM_CASSETTE_MODE=record FAKE_RESPONSES_FILE=$PWD/testdata/responses/generate.json go test -run TestGenerateTestFiles
+//	LLM_CASSETTE_MODE=record FAKE_RESPONSES_FILE=$PWD/testdata/responses/retry.json go test -run TestGenerateRetriedTestFiles
+
+const pricesSource = `def format_price(cents):
+    """Formats an amount of cents as dollars."""
+    if cents == 0:
+        return "$0"
+    return "$%d.%02d" % (cents // 100, cents % 100)
+`
+
+// recordingProvider keeps the requests sent to the provider it wraps
+type recordingProvider struct {
+	providers.Provider
+	requests []*providers.Request
+}
+
+func (p *recordingProvider) Generate(ctx context.Context, req *providers.Request) (*providers.Response, error) {
+	p.requests = append(p.requests, req)
+	return p.Provider.Generate(ctx, req)
+}
+
+func newReplayServer(t *testing.T) (*Server, *models.Models) {
+	t.Helper()
+
+	if os.Getenv("LLM_CASSETTE_MODE") == "" {
+		t.Setenv("LLM_CASSETTE_MODE", providers.CassetteReplay)
+	}
+	t.Setenv("LLM_CASSETTE_DIR", "testdata/cassettes")
+	t.Setenv("LLM_PROVIDER", "fake")
+	for _, key := range []string{"GENERATOR_MODEL", "PARSER_MODEL", "RETRY_MODEL", "GENERATOR_FALLBACKS", "RETRY_FALLBACKS", "MODEL_ALLOWLIST", "GENERATOR_TOKEN_BUDGET", "GENERATOR_WORKERS"} {
+		t.Setenv(key, "")
+	}
+
+	m, err := models.NewModels(context.Background())
+	if err != nil {
+		t.Fatalf("unable to create models: %v", err)
+	}
+
+	_, s := GetGrpcServer()
+	s.models.Store(m)
+	t.Cleanup(s.Close)
+	return s, m
+}
+
+func TestGenerateTestFiles(t *testing.T) {
+	s, _ := newReplayServer(t)
+
+	res, err := s.GenerateTestFiles(context.Background(), &pb.GithubContextRequest{
+		MergeId: "merge_replay_1",
+		Config:  &pb.Configuration{Configuration: &pb.BasicConfig{TestDirectory: "tests", TestingFramework: "pytest"}},
+		Files:   []*pb.SourceFilePayload{{Path: "prices.py", Content: pricesSource}},
+	})
+	if err != nil {
+		t.Fatalf("GenerateTestFiles failed: %v", err)
+	}
+
+	if len(res.GetTests()) != 1 {
+		t.Fatalf("expected 1 test file, got %d", len(res.GetTests()))
+	}
+	test := res.GetTests()[0]
+	if test.GetParentpath() != "prices.py" || test.GetTestfilepath() != "tests/test_prices.py" {
+		t.Errorf("unexpected test paths %q for %q", test.GetTestfilepath(), test.GetParentpath())
+	}
+	if len(test.GetCases()) != 2 {
+		t.Errorf("expected 2 test cases, got %d", len(test.GetCases()))
+	}
+	if len(res.GetSkippedFiles()) != 0 {
+		t.Errorf("expected no skipped files, got %v", res.GetSkippedFiles())
+	}
+	if res.GetPromptVersion() == "" {
+		t.Error("expected the prompt version to be reported")
+	}
+
+	if len(res.GetUsage()) != 1 || res.GetUsage()[0].GetPurpose() != string(prompts.Generate) || res.GetUsage()[0].GetCalls() != 1 {
+		t.Errorf("expected a single generate call in the usage, got %v", res.GetUsage())
+	}
+}
+
+func TestGenerateRetriedTestFiles(t *testing.T) {
+	s, m := newReplayServer(t)
+
+	recorder := &recordingProvider{Provider: m.Retry.Provider}
+	m.Retry.Provider = recorder
+
+	failing := &pb.TestFilePayload{
+		Testfilepath: "tests/test_prices.py",
+		Parentpath:   "prices.py",
+		Code:         "from prices import format_price\n\n\ndef test_formats_zero():\n    assert format_price(0) == \"$0.00\"\n",
+	}
+
+	res, err := s.GenerateRetriedTestFiles(context.Background(), &pb.RetryMechanismPayload{
+		Cache: &pb.CachedContents{
+			MergeId:  "merge_replay_1",
+			Config:   &pb.Configuration{Configuration: &pb.BasicConfig{TestDirectory: "tests", TestingFramework: "pytest", WaterMark: true}},
+			Contexts: []*pb.SourceFilePayload{{Path: "prices.py", Content: pricesSource}},
+			Tests:    []*pb.TestFilePayload{failing},
+		},
+		Logs: []string{
+			"FAILED tests/test_prices.py::test_formats_zero - AssertionError: assert '$0' == '$0.00'",
+			"1 failed, 1 passed in 0.02s",
+		},
+	})
+	if err != nil {
+		t.Fatalf("GenerateRetriedTestFiles failed: %v", err)
+	}
+
+	if len(res.GetTests()) != 1 || res.GetTests()[0].GetTestfilepath() != "tests/test_prices.py" {
+		t.Fatalf("expected tests/test_prices.py to be regenerated, got %v", res.GetTests())
+	}
+	if !strings.Contains(res.GetTests()[0].GetCode(), postprocess.Watermark) {
+		t.Error("expected the cached configuration to watermark the regenerated test")
+	}
+
+	if len(recorder.requests) != 1 {
+		t.Fatalf("expected one regenerate request, got %d", len(recorder.requests))
+	}
+	prompt := strings.Join(recorder.requests[0].Prompt, "\n")
+	for _, want := range []string{`"merge_id":"merge_replay_1"`, `"framework":"pytest"`, `"path":"prices.py"`, `def format_price(cents):`, `def test_formats_zero():`, `format_price(0) returned`} {
+		if !strings.Contains(prompt, want) {
+			t.Errorf("expected the regenerate request to contain %q, got %s", want, prompt)
+		}
+	}
+
+	calls := make(map[string]int64)
+	for _, u := range res.GetUsage() {
+		calls[u.GetPurpose()] += u.GetCalls()
+	}
+	if calls[string(prompts.ParseLogs)] != 1 || calls[string(prompts.Regenerate)] != 1 {
+		t.Errorf("expected one parse-logs and one regenerate call in the usage, got %v", res.GetUsage())
+	}
+}
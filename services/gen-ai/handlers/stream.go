@@ -0,0 +1,122 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/codesourcerer-bot/gen-ai/prompts"
+	"github.com/codesourcerer-bot/gen-ai/providers"
+	pb "github.com/codesourcerer-bot/proto/generated"
+	"google.golang.org/grpc"
+)
+
+// streamTestsFromAI generates the payload in batches like getTestsFromAI, but
+// sends a result for every source file as soon as its batch completes
+func streamTestsFromAI(ctx context.Context, payload *pb.GithubContextRequest, chain []*providers.Model, budget int32, workers int, stream grpc.ServerStreamingServer[pb.TestFileResult]) error {
+	prompt, chain, err := withPrompt(prompts.Generate, payload.GetConfig(), chain)
+	if err != nil {
+		return err
+	}
+
+	batches, skipped := planBatches(ctx, chain[0], prompt.Examples, payload, budget)
+
+	for _, f := range skipped {
+		result := &pb.TestFileResult{Path: f.GetPath(), Result: &pb.TestFileResult_Error{Error: f.GetReason()}, PromptVersion: prompt.Version}
+		if err := stream.Send(result); err != nil {
+			return err
+		}
+	}
+
+	var sendErr error
+	runBatches(ctx, chain, prompt, payload, batches, workers, func(i int, res *pb.GeneratedTestsResponse, usage []*pb.TokenUsage, err error) {
+		if sendErr == nil {
+			sendErr = sendBatchResults(stream, batches[i], prompt.Version, res, usage, err)
+		}
+	})
+
+	return sendErr
+}
+
+// sendBatchResults sends the tests of each file in the batch, or why it has
+// none. The usage of the batch goes with its first result so it is counted once.
+func sendBatchResults(stream grpc.ServerStreamingServer[pb.TestFileResult], b *batch, promptVersion string, res *pb.GeneratedTestsResponse, usage []*pb.TokenUsage, batchErr error) error {
+	send := func(result *pb.TestFileResult) error {
+		result.Usage, usage = usage, nil
+		return stream.Send(result)
+	}
+
+	if batchErr != nil {
+		for _, f := range b.files {
+			result := &pb.TestFileResult{Path: f.GetPath(), Result: &pb.TestFileResult_Error{Error: fmt.Sprintf("generation failed: %v", batchErr)}, PromptVersion: promptVersion}
+			if err := send(result); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	covered := make(map[string]bool)
+	for _, f := range res.GetSkippedFiles() {
+		path := b.match(f.GetPath())
+		covered[path] = true
+		result := &pb.TestFileResult{Path: path, Result: &pb.TestFileResult_Error{Error: f.GetReason()}, Model: res.GetModel(), PromptVersion: promptVersion}
+		if err := send(result); err != nil {
+			return err
+		}
+	}
+
+	for _, test := range res.GetTests() {
+		// The test is reported against the batch file it covers, so the file is
+		// not also reported as missing a test
+		test.Parentpath = b.match(test.GetParentpath())
+		covered[test.GetParentpath()] = true
+		result := &pb.TestFileResult{Path: test.GetParentpath(), Result: &pb.TestFileResult_Test{Test: test}, Model: res.GetModel(), PromptVersion: promptVersion}
+		if err := send(result); err != nil {
+			return err
+		}
+	}
+
+	for _, f := range b.files {
+		if covered[f.GetPath()] {
+			continue
+		}
+		result := &pb.TestFileResult{Path: f.GetPath(), Result: &pb.TestFileResult_Error{Error: "the model generated no test for this file"}, Model: res.GetModel(), PromptVersion: promptVersion}
+		if err := send(result); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// match returns the path of the batch file the model meant by filePath. Paths
+// are compared normalized, then by file name when it is unique in the batch.
+// Anything else is attached to the first file of the batch.
+func (b *batch) match(filePath string) string {
+	normalized := normalizePath(filePath)
+
+	var byName []string
+	for _, f := range b.files {
+		if normalizePath(f.GetPath()) == normalized {
+			return f.GetPath()
+		}
+		if path.Base(normalizePath(f.GetPath())) == path.Base(normalized) {
+			byName = append(byName, f.GetPath())
+		}
+	}
+
+	if len(byName) == 1 {
+		return byName[0]
+	}
+	if len(b.files) > 0 {
+		return b.files[0].GetPath()
+	}
+	return filePath
+}
+
+// normalizePath cleans a repository path so that "./a.py", "/a.py" and "a.py" compare equal
+func normalizePath(filePath string) string {
+	return strings.TrimPrefix(path.Clean("/"+strings.ReplaceAll(filePath, "\\", "/")), "/")
+}
@@ -0,0 +1,129 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/codesourcerer-bot/gen-ai/postprocess"
+	"github.com/codesourcerer-bot/gen-ai/providers"
+	"github.com/codesourcerer-bot/gen-ai/validation"
+	pb "github.com/codesourcerer-bot/proto/generated"
+)
+
+// syntaxRepairAttempts bounds the corrective turns spent on files that do not parse
+const syntaxRepairAttempts = 2
+
+const syntaxPrompt = "These test files do not parse:\n\n%s\nAnswer again with only a JSON object of the same form, containing just these files with their syntax errors fixed, their testfilepath unchanged and their cases kept."
+
+// brokenTest is a generated file with the syntax errors found in it
+type brokenTest struct {
+	test        *pb.TestFilePayload
+	diagnostics []validation.Diagnostic
+}
+
+// repairSyntax parses every generated file and sends the ones with syntax
+// errors back to the model along with the parser diagnostics. Files still
+// broken after the attempts are dropped and reported as skipped, so that
+// they never use up a CI run.
+func repairSyntax(ctx context.Context, chain []*providers.Model, history []providers.Message, prompt []string, res *pb.GeneratedTestsResponse, config *pb.Configuration) {
+	broken := findBrokenTests(res.GetTests())
+	if len(broken) == 0 {
+		return
+	}
+
+	conversation := append(append([]providers.Message(nil), history...), providers.Message{Role: providers.RoleUser, Text: strings.Join(prompt, "\n")})
+	previous := res.GetTests()
+
+	for attempt := 1; attempt <= syntaxRepairAttempts && len(broken) > 0; attempt++ {
+		answer, err := json.Marshal(&pb.GeneratedTestsResponse{Tests: previous})
+		if err != nil {
+			log.Printf("Unable to serialize the generated tests: %v", err)
+			break
+		}
+
+		correction := fmt.Sprintf(syntaxPrompt, describeBrokenTests(broken))
+		log.Printf("Asking the model to fix %d test files with syntax errors, attempt %d of %d", len(broken), attempt, syntaxRepairAttempts)
+
+		conversation = append(conversation, providers.Message{Role: providers.RoleModel, Text: string(answer)})
+		fixed, err := generateTestsWithFallback(ctx, chain, conversation, correction)
+		if err != nil {
+			log.Printf("Unable to fix the syntax errors: %v", err)
+			break
+		}
+		conversation = append(conversation, providers.Message{Role: providers.RoleUser, Text: correction})
+
+		postprocess.Apply(fixed.GetTests(), config)
+		replaceTests(res, broken, fixed.GetTests())
+
+		previous = fixed.GetTests()
+		broken = findBrokenTests(res.GetTests())
+	}
+
+	dropBrokenTests(res, broken)
+}
+
+func findBrokenTests(tests []*pb.TestFilePayload) []brokenTest {
+	var broken []brokenTest
+	for _, test := range tests {
+		if diagnostics, _ := validation.Check(test.GetTestfilepath(), test.GetCode()); len(diagnostics) > 0 {
+			broken = append(broken, brokenTest{test: test, diagnostics: diagnostics})
+		}
+	}
+	return broken
+}
+
+func describeBrokenTests(broken []brokenTest) string {
+	var description strings.Builder
+	for _, b := range broken {
+		fmt.Fprintf(&description, "%s:\n%s\n\n", b.test.GetTestfilepath(), validation.Summary(b.diagnostics))
+	}
+	return description.String()
+}
+
+// replaceTests swaps broken files for their fixed versions, matched by path.
+// Files the model was not asked about are ignored.
+func replaceTests(res *pb.GeneratedTestsResponse, broken []brokenTest, fixed []*pb.TestFilePayload) {
+	byPath := make(map[string]*pb.TestFilePayload, len(fixed))
+	for _, test := range fixed {
+		byPath[test.GetTestfilepath()] = test
+	}
+
+	for _, b := range broken {
+		replacement, ok := byPath[b.test.GetTestfilepath()]
+		if !ok {
+			continue
+		}
+		for i, test := range res.Tests {
+			if test == b.test {
+				res.Tests[i] = replacement
+			}
+		}
+	}
+}
+
+func dropBrokenTests(res *pb.GeneratedTestsResponse, broken []brokenTest) {
+	if len(broken) == 0 {
+		return
+	}
+
+	dropped := make(map[*pb.TestFilePayload]bool, len(broken))
+	for _, b := range broken {
+		dropped[b.test] = true
+		log.Printf("Dropping %s: syntax errors remain after %d attempts", b.test.GetTestfilepath(), syntaxRepairAttempts)
+		res.SkippedFiles = append(res.SkippedFiles, &pb.SkippedFile{
+			Path:   b.test.GetParentpath(),
+			Reason: fmt.Sprintf("the generated test %s does not parse: %s", b.test.GetTestfilepath(), b.diagnostics[0]),
+		})
+	}
+
+	kept := res.Tests[:0]
+	for _, test := range res.Tests {
+		if !dropped[test] {
+			kept = append(kept, test)
+		}
+	}
+	res.Tests = kept
+}
@@ -0,0 +1,34 @@
+package handlers
+
+import (
+	"github.com/codesourcerer-bot/gen-ai/prompts"
+	"github.com/codesourcerer-bot/gen-ai/providers"
+	pb "github.com/codesourcerer-bot/proto/generated"
+)
+
+// withUsage returns copies of the chain whose calls are recorded in the ledger under purpose
+func withUsage(chain []*providers.Model, ledger *providers.UsageLedger, purpose prompts.Purpose) []*providers.Model {
+	recorded := make([]*providers.Model, 0, len(chain))
+	for _, model := range chain {
+		copied := *model
+		copied.Provider = providers.NewUsageProvider(model.Provider, ledger, string(purpose))
+		recorded = append(recorded, &copied)
+	}
+	return recorded
+}
+
+// usageProtos converts the totals of the ledger for the response
+func usageProtos(ledger *providers.UsageLedger) []*pb.TokenUsage {
+	var usage []*pb.TokenUsage
+	for _, entry := range ledger.Entries() {
+		usage = append(usage, &pb.TokenUsage{
+			Purpose:          entry.Purpose,
+			Model:            entry.Model,
+			PromptTokens:     entry.InputTokens,
+			CompletionTokens: entry.OutputTokens,
+			TotalTokens:      entry.InputTokens + entry.OutputTokens,
+			Calls:            entry.Calls,
+		})
+	}
+	return usage
+}
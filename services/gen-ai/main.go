@@ -1,7 +1,11 @@
 package main
 
 import (
+	"context"
 	"log"
+	"os"
+	"os/signal"
+	"syscall"
 
 	"github.com/codesourcerer-bot/gen-ai/handlers"
 	"github.com/codesourcerer-bot/gen-ai/utils"
@@ -12,7 +16,20 @@ func main() {
 	utils.LoadEnv()
 	lis, port := utils.GetListener()
 
-	grpcServer := handlers.GetGrpcServer()
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	grpcServer, server := handlers.GetGrpcServer()
+	defer server.Close()
+
+	go server.InitializeModels(ctx)
+
+	go func() {
+		<-ctx.Done()
+		log.Println("Shutting down GenAI gRPC Server")
+		server.Shutdown()
+		grpcServer.GracefulStop()
+	}()
 
 	log.Println("GenAI gRPC Server started at PORT ", port)
 
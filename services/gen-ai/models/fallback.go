@@ -0,0 +1,71 @@
+package models
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/codesourcerer-bot/gen-ai/providers"
+	pb "github.com/codesourcerer-bot/proto/generated"
+)
+
+// loadFallbacks builds the models listed in <prefix>_FALLBACKS, an ordered and
+// comma-separated list of provider:model entries. Each fallback keeps the
+// instruction and sampling settings of the primary model.
+func (m *Models) loadFallbacks(ctx context.Context, primary *providers.Model, prefix string) ([]*providers.Model, error) {
+	var fallbacks []*providers.Model
+
+	for _, entry := range strings.Split(os.Getenv(prefix+"_FALLBACKS"), ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		name, modelName, ok := strings.Cut(entry, ":")
+		if !ok || name == "" || modelName == "" {
+			return nil, fmt.Errorf("invalid %s_FALLBACKS entry %q, expected provider:model", prefix, entry)
+		}
+
+		provider, err := m.provider(ctx, strings.ToLower(name))
+		if err != nil {
+			return nil, err
+		}
+
+		fallback := *primary
+		fallback.Provider = provider
+		fallback.Name = modelName
+		fallbacks = append(fallbacks, &fallback)
+	}
+
+	return fallbacks, nil
+}
+
+// provider returns the named provider, creating it on first use
+func (m *Models) provider(ctx context.Context, name string) (providers.Provider, error) {
+	if provider, ok := m.providers[name]; ok {
+		return provider, nil
+	}
+
+	provider, err := providers.NewNamedProvider(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	m.providers[name] = provider
+	return provider, nil
+}
+
+// samplingOnly drops the model name from the overrides, keeping the sampling settings
+func samplingOnly(config *pb.ModelConfig) *pb.ModelConfig {
+	if config == nil {
+		return nil
+	}
+
+	return &pb.ModelConfig{
+		Temperature:     config.Temperature,
+		TopK:            config.TopK,
+		TopP:            config.TopP,
+		MaxOutputTokens: config.MaxOutputTokens,
+	}
+}
@@ -0,0 +1,123 @@
+package models
+
+import (
+	"context"
+
+	"github.com/codesourcerer-bot/gen-ai/providers"
+	pb "github.com/codesourcerer-bot/proto/generated"
+)
+
+const (
+	defaultTokenBudget = 100000
+	defaultWorkers     = 4
+)
+
+// Models holds the model handles shared by every request. The primary provider
+// backs the three models, while fallbacks may use other providers.
+type Models struct {
+	Provider  providers.Provider
+	Generator *providers.Model
+	Parser    *providers.Model
+	Retry     *providers.Model
+
+	GeneratorFallbacks []*providers.Model
+	RetryFallbacks     []*providers.Model
+
+	// TokenBudget bounds the input of one generation request, larger requests are batched
+	TokenBudget int32
+	// Workers bounds the batches generated concurrently
+	Workers int
+
+	providers map[string]providers.Provider
+	allowlist map[string]allowedModel
+}
+
+func NewModels(ctx context.Context) (*Models, error) {
+	provider, err := providers.NewProvider(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	m := &Models{
+		Provider:  provider,
+		providers: map[string]providers.Provider{provider.Name(): provider},
+	}
+
+	if err := m.initialize(ctx); err != nil {
+		m.Close()
+		return nil, err
+	}
+
+	return m, nil
+}
+
+func (m *Models) initialize(ctx context.Context) error {
+	var err error
+
+	if m.Generator, err = InitializeGeneratorModel(m.Provider); err != nil {
+		return err
+	}
+	if m.Parser, err = InitializeParserModel(m.Provider); err != nil {
+		return err
+	}
+	if m.Retry, err = InitializeRetryModel(m.Provider); err != nil {
+		return err
+	}
+
+	if m.GeneratorFallbacks, err = m.loadFallbacks(ctx, m.Generator, "GENERATOR"); err != nil {
+		return err
+	}
+	if m.RetryFallbacks, err = m.loadFallbacks(ctx, m.Retry, "RETRY"); err != nil {
+		return err
+	}
+	if m.allowlist, err = m.loadAllowlist(ctx); err != nil {
+		return err
+	}
+
+	m.TokenBudget, m.Workers = defaultTokenBudget, defaultWorkers
+	if budget, err := envPositiveInt("GENERATOR_TOKEN_BUDGET"); err != nil {
+		return err
+	} else if budget != nil {
+		m.TokenBudget = *budget
+	}
+	if workers, err := envPositiveInt("GENERATOR_WORKERS"); err != nil {
+		return err
+	} else if workers != nil {
+		m.Workers = int(*workers)
+	}
+
+	return nil
+}
+
+// GeneratorChain returns the generator followed by its fallbacks, with the
+// repository overrides applied. Only the primary model can be renamed.
+func (m *Models) GeneratorChain(config *pb.ModelConfig) []*providers.Model {
+	return m.chain(m.Generator, m.GeneratorFallbacks, config)
+}
+
+// RetryChain returns the retry model followed by its fallbacks, with the
+// repository overrides applied like for generation
+func (m *Models) RetryChain(config *pb.ModelConfig) []*providers.Model {
+	return m.chain(m.Retry, m.RetryFallbacks, config)
+}
+
+func (m *Models) chain(primary *providers.Model, fallbacks []*providers.Model, config *pb.ModelConfig) []*providers.Model {
+	chain := []*providers.Model{m.withOverrides(primary, config)}
+
+	sampling := samplingOnly(config)
+	for _, fallback := range fallbacks {
+		chain = append(chain, m.withOverrides(fallback, sampling))
+	}
+
+	return chain
+}
+
+func (m *Models) Close() error {
+	var firstErr error
+	for _, provider := range m.providers {
+		if err := provider.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
@@ -0,0 +1,22 @@
+package models
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/codesourcerer-bot/gen-ai/providers"
+)
+
+// modelName reads the model for a purpose from envKey. The Gemini and fake
+// backends fall back to the Gemini default, the other backends need it to be set.
+func modelName(provider providers.Provider, envKey, geminiDefault string) (string, error) {
+	if name := os.Getenv(envKey); name != "" {
+		return name, nil
+	}
+
+	if provider.Name() == "gemini" || provider.Name() == "fake" {
+		return geminiDefault, nil
+	}
+
+	return "", fmt.Errorf("%s must be set for the %s provider", envKey, provider.Name())
+}
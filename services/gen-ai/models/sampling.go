@@ -0,0 +1,176 @@
+package models
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/codesourcerer-bot/gen-ai/providers"
+	pb "github.com/codesourcerer-bot/proto/generated"
+)
+
+func float32Pointer(v float32) *float32 {
+	return &v
+}
+
+// applySamplingEnv overrides the built-in sampling defaults of a model with
+// <prefix>_TEMPERATURE, <prefix>_TOP_K, <prefix>_TOP_P and <prefix>_MAX_OUTPUT_TOKENS
+func applySamplingEnv(model *providers.Model, prefix string) error {
+	if v, err := envFloat(prefix + "_TEMPERATURE"); err != nil {
+		return err
+	} else if v != nil {
+		model.Temperature = v
+	}
+
+	if v, err := envInt(prefix + "_TOP_K"); err != nil {
+		return err
+	} else if v != nil {
+		model.TopK = *v
+	}
+
+	if v, err := envFloat(prefix + "_TOP_P"); err != nil {
+		return err
+	} else if v != nil {
+		model.TopP = v
+	}
+
+	if v, err := envInt(prefix + "_MAX_OUTPUT_TOKENS"); err != nil {
+		return err
+	} else if v != nil {
+		model.MaxOutputTokens = *v
+	}
+
+	return nil
+}
+
+func envFloat(key string) (*float32, error) {
+	raw := os.Getenv(key)
+	if raw == "" {
+		return nil, nil
+	}
+	v, err := strconv.ParseFloat(raw, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %v", key, err)
+	}
+	return float32Pointer(float32(v)), nil
+}
+
+func envInt(key string) (*int32, error) {
+	raw := os.Getenv(key)
+	if raw == "" {
+		return nil, nil
+	}
+	v, err := strconv.ParseInt(raw, 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %v", key, err)
+	}
+	n := int32(v)
+	return &n, nil
+}
+
+// envPositiveInt is envInt for settings that cannot be below 1, such as a
+// budget or a worker count
+func envPositiveInt(key string) (*int32, error) {
+	v, err := envInt(key)
+	if err != nil {
+		return nil, err
+	}
+	if v != nil && *v < 1 {
+		return nil, fmt.Errorf("invalid %s: must be at least 1, got %d", key, *v)
+	}
+	return v, nil
+}
+
+// allowedModel is a model repositories may pick, with the provider serving it
+type allowedModel struct {
+	provider providers.Provider
+	name     string
+}
+
+// loadAllowlist reads the comma-separated MODEL_ALLOWLIST of models repositories
+// may pick. Entries are provider:model, or a bare model of the primary provider.
+// Their providers are created up front so that requests never create one.
+func (m *Models) loadAllowlist(ctx context.Context) (map[string]allowedModel, error) {
+	allowlist := make(map[string]allowedModel)
+	for _, entry := range strings.Split(os.Getenv("MODEL_ALLOWLIST"), ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		providerName, name := m.modelRef(entry)
+		provider, err := m.provider(ctx, providerName)
+		if err != nil {
+			return nil, fmt.Errorf("invalid MODEL_ALLOWLIST entry %q: %v", entry, err)
+		}
+		allowlist[providerName+":"+name] = allowedModel{provider: provider, name: name}
+	}
+	return allowlist, nil
+}
+
+// modelRef splits provider:model. Without a known provider prefix, like for an
+// Ollama tag such as llama3:8b, the model belongs to the primary provider.
+func (m *Models) modelRef(ref string) (string, string) {
+	if prefix, name, ok := strings.Cut(ref, ":"); ok && providers.IsBackend(strings.ToLower(prefix)) {
+		return strings.ToLower(prefix), name
+	}
+	return m.Provider.Name(), ref
+}
+
+// withOverrides returns a copy of the model with the repository overrides applied.
+// A renamed model is sent to the provider serving it. Names outside the allowlist
+// and out of range settings are ignored.
+func (m *Models) withOverrides(model *providers.Model, config *pb.ModelConfig) *providers.Model {
+	if config == nil {
+		return model
+	}
+
+	overridden := *model
+
+	if ref := config.GetName(); ref != "" {
+		providerName, name := m.modelRef(ref)
+		if allowed, ok := m.allowlist[providerName+":"+name]; ok {
+			overridden.Provider = allowed.provider
+			overridden.Name = allowed.name
+		} else if name != model.Name || providerName != model.Provider.Name() {
+			log.Printf("Ignoring model %q, it is not in the allowlist", ref)
+		}
+	}
+
+	if config.Temperature != nil {
+		if t := config.GetTemperature(); t >= 0 && t <= 2 {
+			overridden.Temperature = float32Pointer(t)
+		} else {
+			log.Printf("Ignoring out of range temperature %v", t)
+		}
+	}
+
+	if config.TopK != nil {
+		if k := config.GetTopK(); k >= 1 {
+			overridden.TopK = k
+		} else {
+			log.Printf("Ignoring out of range top-k %v", k)
+		}
+	}
+
+	if config.TopP != nil {
+		if p := config.GetTopP(); p > 0 && p <= 1 {
+			overridden.TopP = float32Pointer(p)
+		} else {
+			log.Printf("Ignoring out of range top-p %v", p)
+		}
+	}
+
+	if config.MaxOutputTokens != nil {
+		if n := config.GetMaxOutputTokens(); n >= 1 {
+			overridden.MaxOutputTokens = n
+		} else {
+			log.Printf("Ignoring out of range max-output-tokens %v", n)
+		}
+	}
+
+	return &overridden
+}
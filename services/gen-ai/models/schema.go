@@ -0,0 +1,38 @@
+package models
+
+import "github.com/codesourcerer-bot/gen-ai/providers"
+
+// testsResponseSchema is the shape of GeneratedTestsResponse as the models must produce it
+var testsResponseSchema = &providers.Schema{
+	Type:     "object",
+	Required: []string{"tests"},
+	Properties: map[string]*providers.Schema{
+		"tests": {
+			Type: "array",
+			Items: &providers.Schema{
+				Type:     "object",
+				Required: []string{"testname", "testfilepath", "parentpath", "code"},
+				Properties: map[string]*providers.Schema{
+					"testname":     {Type: "string", Description: "Name of the test suite, test_<file_name>"},
+					"testfilepath": {Type: "string", Description: "Path of the generated test file"},
+					"parentpath":   {Type: "string", Description: "Path of the file under test"},
+					"code":         {Type: "string", Description: "Complete code of the test file"},
+					"cases": {
+						Type:        "array",
+						Description: "Every test case in the code, in order",
+						Items: &providers.Schema{
+							Type:     "object",
+							Required: []string{"name", "target", "category", "rationale"},
+							Properties: map[string]*providers.Schema{
+								"name":      {Type: "string", Description: "Name of the test function or subtest"},
+								"target":    {Type: "string", Description: "Function, method or class the case exercises"},
+								"category":  {Type: "string", Enum: []string{"happy_path", "edge_case", "error"}},
+								"rationale": {Type: "string", Description: "One line explaining what the case verifies"},
+							},
+						},
+					},
+				},
+			},
+		},
+	},
+}
@@ -0,0 +1,214 @@
+package postprocess
+
+import (
+	"path"
+	"strings"
+)
+
+// syntax describes the comments and string literals of a language, which is
+// all the post-processor needs to know to leave strings untouched
+type syntax struct {
+	lineComment  string
+	blockComment bool
+	// quotes are the string delimiters, longest first. Only triple quotes and
+	// backticks may span lines.
+	quotes []string
+	// regex enables JavaScript regular expression literals
+	regex bool
+	// formatted languages are indented by their formatter
+	formatted bool
+	// significantIndent languages only indent blocks by statement, lines inside
+	// brackets are continuations aligned by hand
+	significantIndent bool
+}
+
+var (
+	hashSyntax   = &syntax{lineComment: "#", quotes: []string{`"`, `'`}}
+	pythonSyntax = &syntax{lineComment: "#", quotes: []string{`"""`, `'''`, `"`, `'`}, significantIndent: true}
+	goSyntax     = &syntax{lineComment: "//", blockComment: true, quotes: []string{"`", `"`, `'`}, formatted: true}
+	jsSyntax     = &syntax{lineComment: "//", blockComment: true, quotes: []string{"`", `"`, `'`}, regex: true}
+	cSyntax      = &syntax{lineComment: "//", blockComment: true, quotes: []string{`"""`, `"`, `'`}}
+)
+
+// syntaxFor picks the syntax from the extension of the test file
+func syntaxFor(filePath string) *syntax {
+	switch path.Ext(filePath) {
+	case ".py":
+		return pythonSyntax
+	case ".rb", ".sh":
+		return hashSyntax
+	case ".go":
+		return goSyntax
+	case ".js", ".jsx", ".mjs", ".cjs", ".ts", ".tsx", ".mts", ".cts":
+		return jsSyntax
+	case ".java", ".kt", ".kts", ".scala", ".cs", ".c", ".cc", ".cpp", ".h", ".hpp", ".swift":
+		return cSyntax
+	}
+	return nil
+}
+
+type segmentKind int
+
+const (
+	codeSegment segmentKind = iota
+	stringSegment
+	commentSegment
+)
+
+// segment is a run of code, a string literal or a comment. Line comments stop
+// before their newline, which belongs to the code that follows.
+type segment struct {
+	kind segmentKind
+	text string
+}
+
+// split cuts the source into code, string and comment segments
+func (s *syntax) split(content string) []segment {
+	var segments []segment
+	codeStart := 0
+
+	flush := func(end int) {
+		if end > codeStart {
+			segments = append(segments, segment{kind: codeSegment, text: content[codeStart:end]})
+		}
+	}
+
+	for i := 0; i < len(content); {
+		rest := content[i:]
+		end := -1
+		kind := codeSegment
+
+		switch {
+		case strings.HasPrefix(rest, s.lineComment):
+			end = i + indexOrEnd(rest, "\n")
+			kind = commentSegment
+
+		case s.blockComment && strings.HasPrefix(rest, "/*"):
+			if j := strings.Index(rest[2:], "*/"); j >= 0 {
+				end = i + j + 4
+			} else {
+				end = len(content)
+			}
+			kind = commentSegment
+
+		case s.regex && rest[0] == '/' && startsRegex(content[codeStart:i], segments):
+			end = i + regexLength(rest)
+			kind = stringSegment
+
+		default:
+			if quote := s.quoteAt(rest); quote != "" {
+				end = i + stringLength(rest, quote)
+				kind = stringSegment
+			}
+		}
+
+		if kind == codeSegment {
+			i++
+			continue
+		}
+
+		flush(i)
+		segments = append(segments, segment{kind: kind, text: content[i:end]})
+		i, codeStart = end, end
+	}
+
+	flush(len(content))
+	return segments
+}
+
+func (s *syntax) quoteAt(rest string) string {
+	for _, quote := range s.quotes {
+		if strings.HasPrefix(rest, quote) {
+			return quote
+		}
+	}
+	return ""
+}
+
+func indexOrEnd(s, sub string) int {
+	if i := strings.Index(s, sub); i >= 0 {
+		return i
+	}
+	return len(s)
+}
+
+// stringLength measures the literal opened by quote at the start of rest. Single
+// line strings end at the newline when they are not closed.
+func stringLength(rest, quote string) int {
+	multiline := len(quote) == 3 || quote == "`"
+
+	for i := len(quote); i < len(rest); i++ {
+		switch {
+		case rest[i] == '\\' && quote != "`":
+			i++
+		case strings.HasPrefix(rest[i:], quote):
+			return i + len(quote)
+		case rest[i] == '\n' && !multiline:
+			return i
+		}
+	}
+	return len(rest)
+}
+
+// startsRegex reports whether a slash opens a regular expression rather than a
+// division, judging from the code before it
+func startsRegex(code string, segments []segment) bool {
+	before := strings.TrimRight(code, " \t\r\n")
+	if before == "" {
+		if len(segments) == 0 {
+			return true
+		}
+		last := segments[len(segments)-1]
+		switch last.kind {
+		case stringSegment:
+			return false
+		case commentSegment:
+			return true
+		}
+		before = strings.TrimRight(last.text, " \t\r\n")
+		if before == "" {
+			return true
+		}
+	}
+
+	if strings.ContainsRune("(,=:[!&|?{};+-*%<>~^", rune(before[len(before)-1])) {
+		return true
+	}
+	for _, keyword := range []string{"return", "typeof", "case", "in", "of"} {
+		if strings.HasSuffix(before, keyword) && (len(before) == len(keyword) || !isIdentByte(before[len(before)-len(keyword)-1])) {
+			return true
+		}
+	}
+	return false
+}
+
+func isIdentByte(c byte) bool {
+	return c == '_' || c == '$' || c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+// regexLength measures a regular expression literal including its flags
+func regexLength(rest string) int {
+	inClass := false
+	for i := 1; i < len(rest); i++ {
+		switch rest[i] {
+		case '\\':
+			i++
+		case '[':
+			inClass = true
+		case ']':
+			inClass = false
+		case '\n':
+			return i
+		case '/':
+			if inClass {
+				continue
+			}
+			i++
+			for i < len(rest) && isIdentByte(rest[i]) {
+				i++
+			}
+			return i
+		}
+	}
+	return len(rest)
+}
@@ -0,0 +1,270 @@
+package postprocess
+
+import (
+	"go/format"
+	"log"
+	"regexp"
+	"strconv"
+	"strings"
+
+	pb "github.com/codesourcerer-bot/proto/generated"
+)
+
+// Watermark is the comment added to every generated test file when water-mark is on
+const Watermark = "Coughed up by CODESOURCERER"
+
+// pragmaRegex matches comments that tools read, which are kept when comments are stripped
+var pragmaRegex = regexp.MustCompile(`^(?:#!|#\s*-\*-|#\s*(?:noqa|type:|pragma|pylint:|fmt:)|//go:|//\s*\+build|//\s*nolint|//\s*(?:eslint|@ts-|prettier-ignore)|/\*\s*(?:eslint|istanbul|global))`)
+
+// Options are the repository settings enforced on the generated code
+type Options struct {
+	WaterMark  bool
+	Comments   bool
+	IndentSize int
+}
+
+// OptionsFromConfig reads the options from the generation config. The second
+// result is false when the request carries no config to enforce.
+func OptionsFromConfig(config *pb.Configuration) (Options, bool) {
+	basic := config.GetConfiguration()
+	if basic == nil {
+		return Options{}, false
+	}
+
+	opts := Options{WaterMark: basic.GetWaterMark(), Comments: basic.GetComments()}
+	if size, err := strconv.Atoi(config.GetExtras()["indent-size"]); err == nil && size > 0 {
+		opts.IndentSize = size
+	}
+	return opts, true
+}
+
+// Apply post-processes the code of every test in place. Without a config only
+// the formatting of languages with a standard formatter is applied.
+func Apply(tests []*pb.TestFilePayload, config *pb.Configuration) {
+	opts, configured := OptionsFromConfig(config)
+
+	for _, test := range tests {
+		if configured {
+			test.Code = Code(test.GetTestfilepath(), test.GetCode(), opts)
+		} else {
+			test.Code = formatCode(test.GetTestfilepath(), test.GetCode())
+		}
+	}
+}
+
+// Code applies the options to the code of one test file. Files in a language
+// it does not know are returned unchanged.
+func Code(filePath, code string, opts Options) string {
+	s := syntaxFor(filePath)
+	if s == nil {
+		return code
+	}
+
+	code = removeWatermark(code)
+
+	if !opts.Comments {
+		code = stripComments(s, code)
+	}
+
+	if opts.IndentSize > 0 && !s.formatted {
+		code = reindent(s, code, opts.IndentSize)
+	}
+
+	if opts.WaterMark {
+		code = addWatermark(s, code)
+	}
+
+	return formatCode(filePath, code)
+}
+
+// formatCode runs the standard formatter of the language, keeping the code as
+// is when it does not parse
+func formatCode(filePath, code string) string {
+	if syntaxFor(filePath) != goSyntax {
+		return code
+	}
+
+	formatted, err := format.Source([]byte(code))
+	if err != nil {
+		log.Printf("Unable to format %s: %v", filePath, err)
+		return code
+	}
+	return string(formatted)
+}
+
+func watermarkComment(s *syntax) string {
+	return s.lineComment + " " + Watermark
+}
+
+// removeWatermark drops watermark lines the model wrote, in any comment
+// syntax, along with the blank line that separated them from the code
+func removeWatermark(code string) string {
+	lines := strings.Split(code, "\n")
+	kept := make([]string, 0, len(lines))
+	removed, skipBlank := false, false
+
+	for _, line := range lines {
+		if skipBlank && strings.TrimSpace(line) == "" {
+			skipBlank = false
+			continue
+		}
+		skipBlank = false
+
+		if isWatermarkLine(line) {
+			removed, skipBlank = true, true
+			continue
+		}
+		kept = append(kept, line)
+	}
+
+	if !removed {
+		return code
+	}
+	return strings.TrimRight(strings.Join(kept, "\n"), "\n") + "\n"
+}
+
+func isWatermarkLine(line string) bool {
+	trimmed := strings.TrimSpace(line)
+	if !strings.HasPrefix(trimmed, "#") && !strings.HasPrefix(trimmed, "//") && !strings.HasPrefix(trimmed, "/*") {
+		return false
+	}
+	text := strings.TrimSuffix(strings.TrimLeft(trimmed, "#/*"), "*/")
+	return strings.TrimSpace(text) == Watermark
+}
+
+// addWatermark puts the watermark header at the top of the file, after the
+// lines that must come first such as a shebang or an encoding declaration
+func addWatermark(s *syntax, code string) string {
+	lines := strings.SplitAfter(code, "\n")
+
+	at := 0
+	for at < len(lines) && at < 2 && (strings.HasPrefix(lines[at], "#!") || strings.HasPrefix(lines[at], "# -*-") || strings.HasPrefix(lines[at], "# vim:")) {
+		at++
+	}
+
+	header := watermarkComment(s) + "\n\n"
+	return strings.Join(lines[:at], "") + header + strings.Join(lines[at:], "")
+}
+
+// stripComments removes every comment except pragmas. Lines left empty by the
+// removal are dropped, and trailing spaces before a removed comment are trimmed.
+func stripComments(s *syntax, code string) string {
+	var out strings.Builder
+	stripped := make(map[int]bool)
+	line := 0
+
+	for _, seg := range s.split(code) {
+		if seg.kind == commentSegment && !pragmaRegex.MatchString(seg.text) {
+			stripped[line] = true
+			continue
+		}
+		out.WriteString(seg.text)
+		line += strings.Count(seg.text, "\n")
+	}
+
+	lines := strings.Split(out.String(), "\n")
+	kept := make([]string, 0, len(lines))
+	for i, l := range lines {
+		if !stripped[i] {
+			kept = append(kept, l)
+			continue
+		}
+		if l = strings.TrimRight(l, " \t"); strings.TrimSpace(l) != "" {
+			kept = append(kept, l)
+		}
+	}
+	return strings.Join(kept, "\n")
+}
+
+// reindent rescales the indentation of the code to size spaces per level. The
+// current unit is the most common increase of indentation from one line to the
+// next, so aligned continuation lines do not skew it. Indentation past the last
+// full level is kept, and lines starting inside a string are left alone. With
+// significant indentation, lines inside brackets move with their statement.
+func reindent(s *syntax, code string, size int) string {
+	lines := strings.Split(code, "\n")
+	starts := lineStarts(s, code)
+
+	increases := make(map[int]int)
+	previous := 0
+	for i, l := range lines {
+		if !starts[i].inCode || strings.TrimSpace(l) == "" || (s.significantIndent && starts[i].depth > 0) {
+			continue
+		}
+		width := indentWidth(l)
+		if width > previous {
+			increases[width-previous]++
+		}
+		previous = width
+	}
+
+	unit := 0
+	for increase, count := range increases {
+		if count > increases[unit] || (count == increases[unit] && increase < unit) {
+			unit = increase
+		}
+	}
+
+	if unit == 0 || unit == size {
+		return code
+	}
+
+	shift := 0
+	for i, l := range lines {
+		if !starts[i].inCode || strings.TrimSpace(l) == "" {
+			continue
+		}
+
+		width := indentWidth(l)
+		if !s.significantIndent || starts[i].depth == 0 {
+			shift = width/unit*size + width%unit - width
+		}
+		lines[i] = strings.Repeat(" ", max(width+shift, 0)) + strings.TrimLeft(l, " \t")
+	}
+	return strings.Join(lines, "\n")
+}
+
+// lineStart describes where a line starts: outside any string or comment, and
+// at which bracket depth
+type lineStart struct {
+	inCode bool
+	depth  int
+}
+
+func lineStarts(s *syntax, code string) []lineStart {
+	starts := []lineStart{{inCode: true}}
+	depth := 0
+
+	for _, seg := range s.split(code) {
+		for _, c := range seg.text {
+			if seg.kind == codeSegment {
+				switch c {
+				case '(', '[', '{':
+					depth++
+				case ')', ']', '}':
+					depth = max(depth-1, 0)
+				}
+			}
+			if c == '\n' {
+				starts = append(starts, lineStart{inCode: seg.kind == codeSegment, depth: depth})
+			}
+		}
+	}
+	return starts
+}
+
+// indentWidth measures the leading whitespace, counting a tab as four spaces
+func indentWidth(line string) int {
+	width := 0
+	for _, c := range line {
+		switch c {
+		case ' ':
+			width++
+		case '\t':
+			width += 4
+		default:
+			return width
+		}
+	}
+	return width
+}
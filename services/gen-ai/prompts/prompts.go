@@ -0,0 +1,195 @@
+package prompts
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"path"
+	"strings"
+	"text/template"
+
+	"github.com/codesourcerer-bot/gen-ai/providers"
+	pb "github.com/codesourcerer-bot/proto/generated"
+)
+
+// Purpose names what a prompt is used for. Each purpose has a default template
+// that framework directories may override block by block.
+type Purpose string
+
+const (
+	Generate   Purpose = "generate"
+	ParseLogs  Purpose = "parse-logs"
+	Regenerate Purpose = "regenerate"
+)
+
+const defaultFramework = "default"
+
+//go:embed templates
+var files embed.FS
+
+// Data holds the values available to the templates
+type Data struct {
+	Framework     string
+	TestDirectory string
+	Comments      bool
+	WaterMark     bool
+	Extras        map[string]string
+}
+
+// Prompt is a rendered system instruction with the few-shot examples sent before the request
+type Prompt struct {
+	Instruction string
+	Examples    []providers.Message
+	// Version identifies the templates that produced the prompt, e.g. generate@1+jest@1
+	Version string
+}
+
+type source struct {
+	template *template.Template
+	examples []providers.Message
+	version  string
+}
+
+type example struct {
+	Role string `json:"role"`
+	Text string `json:"text"`
+}
+
+// sources maps framework/purpose to its parsed template
+var sources = mustLoad()
+
+// Render renders the prompt of the purpose for the framework in config, falling
+// back to the default template when the framework has none
+func Render(purpose Purpose, config *pb.Configuration) (*Prompt, error) {
+	data := Data{Comments: true}
+	if basic := config.GetConfiguration(); basic != nil {
+		data = Data{
+			Framework:     basic.GetTestingFramework(),
+			TestDirectory: basic.GetTestDirectory(),
+			Comments:      basic.GetComments(),
+			WaterMark:     basic.GetWaterMark(),
+		}
+	}
+	data.Extras = config.GetExtras()
+
+	src, ok := sources[sourceKey(data.Framework, purpose)]
+	if !ok {
+		if src, ok = sources[sourceKey(defaultFramework, purpose)]; !ok {
+			return nil, fmt.Errorf("no prompt template for %s", purpose)
+		}
+	}
+
+	var instruction strings.Builder
+	if err := src.template.Execute(&instruction, data); err != nil {
+		return nil, fmt.Errorf("error rendering %s prompt: %v", purpose, err)
+	}
+
+	return &Prompt{
+		Instruction: strings.TrimSpace(instruction.String()),
+		Examples:    src.examples,
+		Version:     src.version,
+	}, nil
+}
+
+func sourceKey(framework string, purpose Purpose) string {
+	return framework + "/" + string(purpose)
+}
+
+// mustLoad parses the embedded templates. The defaults are parsed first so that
+// framework templates can be layered over a clone of them.
+func mustLoad() map[string]*source {
+	settings, err := files.ReadFile("templates/settings.tmpl")
+	if err != nil {
+		panic(err)
+	}
+
+	paths, err := fs.Glob(files, "templates/*/*.tmpl")
+	if err != nil {
+		panic(err)
+	}
+
+	loaded := make(map[string]*source)
+	var overrides []string
+
+	for _, p := range paths {
+		if path.Base(path.Dir(p)) != defaultFramework {
+			overrides = append(overrides, p)
+			continue
+		}
+
+		purpose := strings.TrimSuffix(path.Base(p), ".tmpl")
+		version, body := mustReadTemplate(p)
+
+		t := template.Must(template.New(purpose).Parse(string(settings)))
+		template.Must(t.Parse(body))
+
+		loaded[sourceKey(defaultFramework, Purpose(purpose))] = &source{
+			template: t,
+			examples: mustReadExamples(strings.TrimSuffix(p, ".tmpl") + ".examples.json"),
+			version:  purpose + "@" + version,
+		}
+	}
+
+	for _, p := range overrides {
+		framework := path.Base(path.Dir(p))
+		purpose := Purpose(strings.TrimSuffix(path.Base(p), ".tmpl"))
+
+		base, ok := loaded[sourceKey(defaultFramework, purpose)]
+		if !ok {
+			panic(fmt.Sprintf("%s has no default template to override", p))
+		}
+
+		version, body := mustReadTemplate(p)
+
+		t := template.Must(base.template.Clone())
+		template.Must(t.Parse(body))
+
+		examples := base.examples
+		if _, err := fs.Stat(files, strings.TrimSuffix(p, ".tmpl")+".examples.json"); err == nil {
+			examples = mustReadExamples(strings.TrimSuffix(p, ".tmpl") + ".examples.json")
+		}
+
+		loaded[sourceKey(framework, purpose)] = &source{
+			template: t,
+			examples: examples,
+			version:  base.version + "+" + framework + "@" + version,
+		}
+	}
+
+	return loaded
+}
+
+// mustReadTemplate splits a template file into its version header and body
+func mustReadTemplate(p string) (string, string) {
+	content, err := files.ReadFile(p)
+	if err != nil {
+		panic(err)
+	}
+
+	header, body, ok := strings.Cut(string(content), "\n---\n")
+	version, found := strings.CutPrefix(header, "version:")
+	if !ok || !found || strings.TrimSpace(version) == "" {
+		panic(fmt.Sprintf("%s must start with a version header", p))
+	}
+
+	return strings.TrimSpace(version), body
+}
+
+func mustReadExamples(p string) []providers.Message {
+	content, err := files.ReadFile(p)
+	if err != nil {
+		panic(err)
+	}
+
+	var examples []example
+	if err := json.Unmarshal(content, &examples); err != nil {
+		panic(fmt.Sprintf("%s: %v", p, err))
+	}
+
+	messages := make([]providers.Message, 0, len(examples))
+	for _, e := range examples {
+		messages = append(messages, providers.Message{Role: e.Role, Text: e.Text})
+	}
+	return messages
+}
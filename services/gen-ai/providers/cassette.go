@@ -0,0 +1,162 @@
+package providers
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+const (
+	CassetteRecord = "record"
+	CassetteReplay = "replay"
+)
+
+// cassetteProvider records the exchanges of another provider to files keyed by
+// a hash of the request, or replays them without calling any backend
+type cassetteProvider struct {
+	inner Provider
+	name  string
+	mode  string
+	dir   string
+}
+
+type cassette struct {
+	Request  *Request  `json:"request"`
+	Response *Response `json:"response"`
+}
+
+// countCassette is a recorded token count. Counts decide how files are batched,
+// so replaying them keeps the requests, and their keys, as they were recorded.
+type countCassette struct {
+	Request *Request `json:"request"`
+	Tokens  int32    `json:"tokens"`
+}
+
+// NewCassetteProvider wraps inner, the backend called name, with a record or
+// replay cassette stored in dir. inner is not used when replaying and may be nil.
+func NewCassetteProvider(inner Provider, name, mode, dir string) (Provider, error) {
+	switch mode {
+	case CassetteRecord:
+		if inner == nil {
+			return nil, errors.New("recording cassettes needs a provider")
+		}
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return nil, fmt.Errorf("unable to create cassette directory: %v", err)
+		}
+	case CassetteReplay:
+	default:
+		return nil, fmt.Errorf("unknown cassette mode %q", mode)
+	}
+
+	return &cassetteProvider{inner: inner, name: name, mode: mode, dir: dir}, nil
+}
+
+// CassetteKey identifies a request by the hash of its JSON encoding
+func CassetteKey(req *Request) (string, error) {
+	data, err := json.Marshal(req)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// Name reports the recorded backend so that replays pick the same models
+func (p *cassetteProvider) Name() string {
+	return p.name
+}
+
+// path is the cassette file of the request, with suffix telling the calls apart
+func (p *cassetteProvider) path(req *Request, suffix string) (string, error) {
+	key, err := CassetteKey(req)
+	if err != nil {
+		return "", fmt.Errorf("unable to hash request: %v", err)
+	}
+	return filepath.Join(p.dir, key+suffix), nil
+}
+
+func (p *cassetteProvider) Generate(ctx context.Context, req *Request) (*Response, error) {
+	path, err := p.path(req, ".json")
+	if err != nil {
+		return nil, err
+	}
+
+	if p.mode == CassetteReplay {
+		var recorded cassette
+		if err := readCassette(path, &recorded); err != nil {
+			return nil, err
+		}
+		return recorded.Response, nil
+	}
+
+	res, err := p.inner.Generate(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := writeCassette(path, cassette{Request: req, Response: res}); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (p *cassetteProvider) CountTokens(ctx context.Context, req *Request) (int32, error) {
+	path, err := p.path(req, ".tokens.json")
+	if err != nil {
+		return 0, err
+	}
+
+	if p.mode == CassetteReplay {
+		var recorded countCassette
+		if err := readCassette(path, &recorded); err != nil {
+			return 0, err
+		}
+		return recorded.Tokens, nil
+	}
+
+	tokens, err := p.inner.CountTokens(ctx, req)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := writeCassette(path, countCassette{Request: req, Tokens: tokens}); err != nil {
+		return 0, err
+	}
+	return tokens, nil
+}
+
+func readCassette(path string, recorded interface{}) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("no cassette recorded for request %s: %v", filepath.Base(path), err)
+	}
+
+	if err := json.Unmarshal(data, recorded); err != nil {
+		return fmt.Errorf("unable to parse cassette %s: %v", path, err)
+	}
+	return nil
+}
+
+func writeCassette(path string, recorded interface{}) error {
+	data, err := json.MarshalIndent(recorded, "", "  ")
+	if err != nil {
+		return fmt.Errorf("unable to serialize cassette: %v", err)
+	}
+
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("unable to write cassette %s: %v", path, err)
+	}
+	return nil
+}
+
+func (p *cassetteProvider) Close() error {
+	if p.inner == nil {
+		return nil
+	}
+	return p.inner.Close()
+}
@@ -0,0 +1,108 @@
+package providers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+
+	"google.golang.org/api/googleapi"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// HTTPError is a non-200 answer from an HTTP backend
+type HTTPError struct {
+	URL        string
+	StatusCode int
+	Body       string
+}
+
+func (e *HTTPError) Error() string {
+	return fmt.Sprintf("request to %s failed: %d %s: %s", e.URL, e.StatusCode, http.StatusText(e.StatusCode), e.Body)
+}
+
+// IsRetryable reports whether another model may succeed where this error
+// happened: timeouts, rate limits, quota exhaustion and server side failures
+func IsRetryable(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) {
+		return retryableHTTPStatus(httpErr.StatusCode)
+	}
+
+	var apiErr *googleapi.Error
+	if errors.As(err, &apiErr) {
+		return retryableHTTPStatus(apiErr.Code)
+	}
+
+	if s, ok := status.FromError(err); ok {
+		switch s.Code() {
+		case codes.ResourceExhausted, codes.Unavailable, codes.DeadlineExceeded, codes.Internal:
+			return true
+		}
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
+
+func retryableHTTPStatus(code int) bool {
+	return code == http.StatusTooManyRequests || code == http.StatusRequestTimeout || code >= http.StatusInternalServerError
+}
+
+// StatusCode maps a model error to the gRPC code reported to callers. Failures
+// callers can wait out are ResourceExhausted, DeadlineExceeded or Unavailable,
+// anything else is an Internal error of the service.
+func StatusCode(err error) codes.Code {
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return codes.DeadlineExceeded
+	case errors.Is(err, context.Canceled):
+		return codes.Canceled
+	}
+
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) {
+		return httpStatusCode(httpErr.StatusCode)
+	}
+
+	var apiErr *googleapi.Error
+	if errors.As(err, &apiErr) {
+		return httpStatusCode(apiErr.Code)
+	}
+
+	if s, ok := status.FromError(err); ok {
+		switch s.Code() {
+		case codes.ResourceExhausted, codes.Unavailable, codes.DeadlineExceeded:
+			return s.Code()
+		}
+		return codes.Internal
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return codes.Unavailable
+	}
+	return codes.Internal
+}
+
+func httpStatusCode(code int) codes.Code {
+	switch {
+	case code == http.StatusTooManyRequests:
+		return codes.ResourceExhausted
+	case code == http.StatusRequestTimeout || code == http.StatusGatewayTimeout:
+		return codes.DeadlineExceeded
+	case code >= http.StatusInternalServerError:
+		return codes.Unavailable
+	}
+	return codes.Internal
+}
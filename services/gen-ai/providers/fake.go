@@ -0,0 +1,91 @@
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+)
+
+// FakeProvider answers with scripted responses in order, without any network
+type FakeProvider struct {
+	mu        sync.Mutex
+	responses []FakeResponse
+	requests  []*Request
+}
+
+// FakeResponse is one scripted answer. A non-empty Err is returned as an error
+// instead, as an *HTTPError when StatusCode is set.
+type FakeResponse struct {
+	Text       string `json:"text"`
+	Err        string `json:"error,omitempty"`
+	StatusCode int    `json:"status_code,omitempty"`
+}
+
+func NewFakeProvider(responses ...FakeResponse) *FakeProvider {
+	return &FakeProvider{responses: responses}
+}
+
+// NewFakeProviderFromFile scripts the provider with a JSON array of responses
+func NewFakeProviderFromFile(path string) (*FakeProvider, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read fake responses: %v", err)
+	}
+
+	var responses []FakeResponse
+	if err := json.Unmarshal(data, &responses); err != nil {
+		return nil, fmt.Errorf("unable to parse fake responses: %v", err)
+	}
+
+	return NewFakeProvider(responses...), nil
+}
+
+func (p *FakeProvider) Name() string {
+	return "fake"
+}
+
+func (p *FakeProvider) Generate(_ context.Context, req *Request) (*Response, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.requests = append(p.requests, req)
+
+	if len(p.responses) == 0 {
+		return nil, errors.New("fake provider has no scripted response left")
+	}
+
+	next := p.responses[0]
+	p.responses = p.responses[1:]
+
+	if next.StatusCode != 0 {
+		return nil, &HTTPError{URL: "fake", StatusCode: next.StatusCode, Body: next.Err}
+	}
+	if next.Err != "" {
+		return nil, errors.New(next.Err)
+	}
+
+	return &Response{
+		Text:  next.Text,
+		Model: req.Model,
+		Usage: Usage{InputTokens: estimateTokens(req), OutputTokens: int32((len(next.Text) + 3) / 4)},
+	}, nil
+}
+
+func (p *FakeProvider) CountTokens(_ context.Context, req *Request) (int32, error) {
+	return estimateTokens(req), nil
+}
+
+func (p *FakeProvider) Close() error {
+	return nil
+}
+
+// Requests returns every request received so far
+func (p *FakeProvider) Requests() []*Request {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return append([]*Request(nil), p.requests...)
+}
@@ -0,0 +1,118 @@
+package providers
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/google/generative-ai-go/genai"
+	"google.golang.org/api/option"
+)
+
+type geminiProvider struct {
+	client *genai.Client
+}
+
+func NewGeminiProvider(ctx context.Context, apiKey string) (Provider, error) {
+	client, err := genai.NewClient(ctx, option.WithAPIKey(apiKey))
+	if err != nil {
+		return nil, err
+	}
+	return &geminiProvider{client: client}, nil
+}
+
+func (p *geminiProvider) Name() string {
+	return "gemini"
+}
+
+func (p *geminiProvider) model(req *Request) *genai.GenerativeModel {
+	model := p.client.GenerativeModel(req.Model)
+
+	if req.Temperature != nil {
+		model.SetTemperature(*req.Temperature)
+	}
+	if req.TopK > 0 {
+		model.SetTopK(req.TopK)
+	}
+	if req.TopP != nil {
+		model.SetTopP(*req.TopP)
+	}
+	if req.MaxOutputTokens > 0 {
+		model.SetMaxOutputTokens(req.MaxOutputTokens)
+	}
+
+	model.ResponseMIMEType = "text/plain"
+	if req.JSON {
+		model.ResponseMIMEType = "application/json"
+		model.ResponseSchema = req.Schema.gemini()
+	}
+
+	if req.SystemInstruction != "" {
+		model.SystemInstruction = genai.NewUserContent(genai.Text(req.SystemInstruction))
+	}
+
+	return model
+}
+
+func geminiParts(texts []string) []genai.Part {
+	parts := make([]genai.Part, 0, len(texts))
+	for _, text := range texts {
+		parts = append(parts, genai.Text(text))
+	}
+	return parts
+}
+
+func (p *geminiProvider) Generate(ctx context.Context, req *Request) (*Response, error) {
+	session := p.model(req).StartChat()
+	for _, message := range req.History {
+		session.History = append(session.History, &genai.Content{
+			Role:  message.Role,
+			Parts: []genai.Part{genai.Text(message.Text)},
+		})
+	}
+
+	response, err := session.SendMessage(ctx, geminiParts(req.Prompt)...)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(response.Candidates) == 0 || response.Candidates[0].Content == nil || len(response.Candidates[0].Content.Parts) == 0 {
+		return nil, errors.New("model did not generate any response")
+	}
+
+	var text strings.Builder
+	for _, part := range response.Candidates[0].Content.Parts {
+		if t, ok := part.(genai.Text); ok {
+			text.WriteString(string(t))
+		}
+	}
+
+	res := &Response{Text: text.String(), Model: req.Model}
+	if usage := response.UsageMetadata; usage != nil {
+		res.Usage = Usage{InputTokens: usage.PromptTokenCount, OutputTokens: usage.CandidatesTokenCount}
+	}
+
+	return res, nil
+}
+
+func (p *geminiProvider) CountTokens(ctx context.Context, req *Request) (int32, error) {
+	var texts []string
+	for _, text := range append([]string{req.SystemInstruction}, req.Prompt...) {
+		if text != "" {
+			texts = append(texts, text)
+		}
+	}
+	for _, message := range req.History {
+		texts = append(texts, message.Text)
+	}
+
+	res, err := p.client.GenerativeModel(req.Model).CountTokens(ctx, geminiParts(texts)...)
+	if err != nil {
+		return 0, err
+	}
+	return res.TotalTokens, nil
+}
+
+func (p *geminiProvider) Close() error {
+	return p.client.Close()
+}
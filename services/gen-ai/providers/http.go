@@ -0,0 +1,56 @@
+package providers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// postJSON sends body as JSON and decodes the JSON response into out
+func postJSON(ctx context.Context, client *http.Client, url string, headers map[string]string, body, out interface{}) error {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("error serializing request: %v", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return &HTTPError{URL: url, StatusCode: resp.StatusCode, Body: string(bytes.TrimSpace(data))}
+	}
+
+	return json.Unmarshal(data, out)
+}
+
+// estimateTokens approximates the token count for backends without a counting endpoint
+func estimateTokens(req *Request) int32 {
+	chars := len(req.SystemInstruction)
+	for _, message := range req.History {
+		chars += len(message.Text)
+	}
+	for _, text := range req.Prompt {
+		chars += len(text)
+	}
+	return int32((chars + 3) / 4)
+}
@@ -0,0 +1,94 @@
+package providers
+
+import (
+	"context"
+	"net/http"
+	"strings"
+)
+
+const defaultOllamaHost = "http://localhost:11434"
+
+// ollamaProvider talks to a local Ollama server through its chat API
+type ollamaProvider struct {
+	host   string
+	client *http.Client
+}
+
+func NewOllamaProvider(host string) Provider {
+	if host == "" {
+		host = defaultOllamaHost
+	}
+	return &ollamaProvider{
+		host:   strings.TrimSuffix(host, "/"),
+		client: &http.Client{},
+	}
+}
+
+type ollamaRequest struct {
+	Model    string                 `json:"model"`
+	Messages []openAIMessage        `json:"messages"`
+	Stream   bool                   `json:"stream"`
+	Format   interface{}            `json:"format,omitempty"`
+	Options  map[string]interface{} `json:"options,omitempty"`
+}
+
+type ollamaResponse struct {
+	Model           string        `json:"model"`
+	Message         openAIMessage `json:"message"`
+	PromptEvalCount int32         `json:"prompt_eval_count"`
+	EvalCount       int32         `json:"eval_count"`
+}
+
+func (p *ollamaProvider) Name() string {
+	return "ollama"
+}
+
+func (p *ollamaProvider) Generate(ctx context.Context, req *Request) (*Response, error) {
+	body := ollamaRequest{
+		Model:    req.Model,
+		Messages: openAIMessages(req),
+		Options:  map[string]interface{}{},
+	}
+	if req.JSON && req.Schema != nil {
+		body.Format = req.Schema
+	} else if req.JSON {
+		body.Format = "json"
+	}
+	if req.Temperature != nil {
+		body.Options["temperature"] = *req.Temperature
+	}
+	if req.TopK > 0 {
+		body.Options["top_k"] = req.TopK
+	}
+	if req.TopP != nil {
+		body.Options["top_p"] = *req.TopP
+	}
+	if req.MaxOutputTokens > 0 {
+		body.Options["num_predict"] = req.MaxOutputTokens
+	}
+
+	var response ollamaResponse
+	if err := postJSON(ctx, p.client, p.host+"/api/chat", nil, body, &response); err != nil {
+		return nil, err
+	}
+
+	model := response.Model
+	if model == "" {
+		model = req.Model
+	}
+
+	return &Response{
+		Text:  response.Message.Content,
+		Model: model,
+		Usage: Usage{InputTokens: response.PromptEvalCount, OutputTokens: response.EvalCount},
+	}, nil
+}
+
+func (p *ollamaProvider) CountTokens(_ context.Context, req *Request) (int32, error) {
+	return estimateTokens(req), nil
+}
+
+func (p *ollamaProvider) Close() error {
+	p.client.CloseIdleConnections()
+	return nil
+}
@@ -0,0 +1,125 @@
+package providers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+)
+
+const defaultOpenAIBaseURL = "https://api.openai.com/v1"
+
+// openAIProvider talks to any server implementing the OpenAI chat completions API
+type openAIProvider struct {
+	baseURL string
+	apiKey  string
+	client  *http.Client
+}
+
+func NewOpenAIProvider(baseURL, apiKey string) Provider {
+	if baseURL == "" {
+		baseURL = defaultOpenAIBaseURL
+	}
+	return &openAIProvider{
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		apiKey:  apiKey,
+		client:  &http.Client{},
+	}
+}
+
+type openAIMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type openAIRequest struct {
+	Model          string                 `json:"model"`
+	Messages       []openAIMessage        `json:"messages"`
+	Temperature    *float32               `json:"temperature,omitempty"`
+	TopP           *float32               `json:"top_p,omitempty"`
+	MaxTokens      int32                  `json:"max_tokens,omitempty"`
+	ResponseFormat map[string]interface{} `json:"response_format,omitempty"`
+}
+
+type openAIResponse struct {
+	Model   string `json:"model"`
+	Choices []struct {
+		Message openAIMessage `json:"message"`
+	} `json:"choices"`
+	Usage struct {
+		PromptTokens     int32 `json:"prompt_tokens"`
+		CompletionTokens int32 `json:"completion_tokens"`
+	} `json:"usage"`
+}
+
+func (p *openAIProvider) Name() string {
+	return "openai"
+}
+
+// openAIMessages flattens the request into chat messages, mapping the model role to assistant
+func openAIMessages(req *Request) []openAIMessage {
+	var messages []openAIMessage
+	if req.SystemInstruction != "" {
+		messages = append(messages, openAIMessage{Role: "system", Content: req.SystemInstruction})
+	}
+	for _, message := range req.History {
+		role := "user"
+		if message.Role == RoleModel {
+			role = "assistant"
+		}
+		messages = append(messages, openAIMessage{Role: role, Content: message.Text})
+	}
+	return append(messages, openAIMessage{Role: "user", Content: strings.Join(req.Prompt, "\n")})
+}
+
+func (p *openAIProvider) Generate(ctx context.Context, req *Request) (*Response, error) {
+	body := openAIRequest{
+		Model:       req.Model,
+		Messages:    openAIMessages(req),
+		Temperature: req.Temperature,
+		TopP:        req.TopP,
+		MaxTokens:   req.MaxOutputTokens,
+	}
+	if req.JSON && req.Schema != nil {
+		body.ResponseFormat = map[string]interface{}{
+			"type":        "json_schema",
+			"json_schema": map[string]interface{}{"name": "response", "schema": req.Schema},
+		}
+	} else if req.JSON {
+		body.ResponseFormat = map[string]interface{}{"type": "json_object"}
+	}
+
+	headers := map[string]string{}
+	if p.apiKey != "" {
+		headers["Authorization"] = "Bearer " + p.apiKey
+	}
+
+	var response openAIResponse
+	if err := postJSON(ctx, p.client, p.baseURL+"/chat/completions", headers, body, &response); err != nil {
+		return nil, err
+	}
+
+	if len(response.Choices) == 0 {
+		return nil, errors.New("model did not generate any response")
+	}
+
+	model := response.Model
+	if model == "" {
+		model = req.Model
+	}
+
+	return &Response{
+		Text:  response.Choices[0].Message.Content,
+		Model: model,
+		Usage: Usage{InputTokens: response.Usage.PromptTokens, OutputTokens: response.Usage.CompletionTokens},
+	}, nil
+}
+
+func (p *openAIProvider) CountTokens(_ context.Context, req *Request) (int32, error) {
+	return estimateTokens(req), nil
+}
+
+func (p *openAIProvider) Close() error {
+	p.client.CloseIdleConnections()
+	return nil
+}
@@ -0,0 +1,160 @@
+package providers
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+)
+
+const (
+	RoleUser  = "user"
+	RoleModel = "model"
+)
+
+// Message is one turn of a conversation
+type Message struct {
+	Role string
+	Text string
+}
+
+// Request is a single generation call, independent of the backend serving it
+type Request struct {
+	Model             string
+	SystemInstruction string
+	History           []Message
+	Prompt            []string
+	JSON              bool
+	Schema            *Schema
+
+	// Nil or zero values leave the backend defaults in place
+	Temperature     *float32
+	TopK            int32
+	TopP            *float32
+	MaxOutputTokens int32
+}
+
+// Usage is the token accounting reported by the backend
+type Usage struct {
+	InputTokens  int32
+	OutputTokens int32
+}
+
+type Response struct {
+	Text  string
+	Model string
+	Usage Usage
+}
+
+// Provider is an LLM backend able to chat with history
+type Provider interface {
+	Name() string
+	Generate(ctx context.Context, req *Request) (*Response, error)
+	CountTokens(ctx context.Context, req *Request) (int32, error)
+	Close() error
+}
+
+// NewProvider creates the backend selected by LLM_PROVIDER, defaulting to Gemini
+func NewProvider(ctx context.Context) (Provider, error) {
+	return NewNamedProvider(ctx, DefaultBackend())
+}
+
+// NewNamedProvider creates the named backend. LLM_CASSETTE_MODE wraps it to
+// record its exchanges, or replaces it with the recordings in LLM_CASSETTE_DIR.
+func NewNamedProvider(ctx context.Context, name string) (Provider, error) {
+	mode := strings.ToLower(os.Getenv("LLM_CASSETTE_MODE"))
+	dir := os.Getenv("LLM_CASSETTE_DIR")
+	if dir == "" {
+		dir = "testdata/cassettes"
+	}
+
+	if mode == CassetteReplay {
+		return NewCassetteProvider(nil, name, mode, dir)
+	}
+
+	provider, err := newBackend(ctx, name)
+	if err != nil || mode == "" {
+		return provider, err
+	}
+
+	return NewCassetteProvider(provider, name, mode, dir)
+}
+
+// DefaultBackend is the normalized LLM_PROVIDER
+func DefaultBackend() string {
+	if name := strings.ToLower(os.Getenv("LLM_PROVIDER")); name != "" {
+		return name
+	}
+	return "gemini"
+}
+
+// Backends are the provider names NewNamedProvider accepts
+var Backends = []string{"gemini", "openai", "ollama", "fake"}
+
+// IsBackend reports whether name is one of the Backends
+func IsBackend(name string) bool {
+	for _, backend := range Backends {
+		if backend == name {
+			return true
+		}
+	}
+	return false
+}
+
+func newBackend(ctx context.Context, name string) (Provider, error) {
+	switch name {
+	case "gemini":
+		return NewGeminiProvider(ctx, os.Getenv("GEMINI_API_KEY"))
+	case "openai":
+		return NewOpenAIProvider(os.Getenv("OPENAI_BASE_URL"), os.Getenv("OPENAI_API_KEY")), nil
+	case "ollama":
+		return NewOllamaProvider(os.Getenv("OLLAMA_HOST")), nil
+	case "fake":
+		return NewFakeProviderFromFile(os.Getenv("FAKE_RESPONSES_FILE"))
+	default:
+		return nil, fmt.Errorf("unknown LLM provider %q", name)
+	}
+}
+
+// Model binds a provider to a model name and the settings used for every call
+type Model struct {
+	Provider          Provider
+	Name              string
+	SystemInstruction string
+	JSON              bool
+	Schema            *Schema
+	Temperature       *float32
+	TopK              int32
+	TopP              *float32
+	MaxOutputTokens   int32
+}
+
+func (m *Model) request(history []Message, prompt []string) *Request {
+	return &Request{
+		Model:             m.Name,
+		SystemInstruction: m.SystemInstruction,
+		History:           history,
+		Prompt:            prompt,
+		JSON:              m.JSON,
+		Schema:            m.Schema,
+		Temperature:       m.Temperature,
+		TopK:              m.TopK,
+		TopP:              m.TopP,
+		MaxOutputTokens:   m.MaxOutputTokens,
+	}
+}
+
+// Generate continues the conversation in history with the prompt parts as the next user turn
+func (m *Model) Generate(ctx context.Context, history []Message, prompt ...string) (*Response, error) {
+	return m.Provider.Generate(ctx, m.request(history, prompt))
+}
+
+// CountTokens counts the tokens Generate would send for the same arguments
+func (m *Model) CountTokens(ctx context.Context, history []Message, prompt ...string) (int32, error) {
+	return m.Provider.CountTokens(ctx, m.request(history, prompt))
+}
+
+// CountText counts the tokens of text alone, without the instruction or any history
+func (m *Model) CountText(ctx context.Context, text string) (int32, error) {
+	return m.Provider.CountTokens(ctx, &Request{Model: m.Name, Prompt: []string{text}})
+}
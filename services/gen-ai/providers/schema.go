@@ -0,0 +1,54 @@
+package providers
+
+import "github.com/google/generative-ai-go/genai"
+
+// Schema is the subset of JSON schema declared to backends supporting structured output
+type Schema struct {
+	Type                 string             `json:"type"`
+	Description          string             `json:"description,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	Enum                 []string           `json:"enum,omitempty"`
+	AdditionalProperties *bool              `json:"additionalProperties,omitempty"`
+}
+
+func (s *Schema) gemini() *genai.Schema {
+	if s == nil {
+		return nil
+	}
+
+	schema := &genai.Schema{
+		Description: s.Description,
+		Items:       s.Items.gemini(),
+		Required:    s.Required,
+	}
+
+	switch s.Type {
+	case "object":
+		schema.Type = genai.TypeObject
+	case "array":
+		schema.Type = genai.TypeArray
+	case "string":
+		schema.Type = genai.TypeString
+		if len(s.Enum) > 0 {
+			schema.Format = "enum"
+			schema.Enum = s.Enum
+		}
+	case "integer":
+		schema.Type = genai.TypeInteger
+	case "number":
+		schema.Type = genai.TypeNumber
+	case "boolean":
+		schema.Type = genai.TypeBoolean
+	}
+
+	if len(s.Properties) > 0 {
+		schema.Properties = make(map[string]*genai.Schema, len(s.Properties))
+		for name, property := range s.Properties {
+			schema.Properties[name] = property.gemini()
+		}
+	}
+
+	return schema
+}
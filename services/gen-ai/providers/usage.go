@@ -0,0 +1,83 @@
+package providers
+
+import (
+	"context"
+	"sync"
+)
+
+// UsageEntry totals the tokens of the calls made for one purpose with one model
+type UsageEntry struct {
+	Purpose      string
+	Model        string
+	InputTokens  int64
+	OutputTokens int64
+	Calls        int64
+}
+
+// UsageLedger collects the usage of the calls made through usage providers.
+// It is safe for concurrent use.
+type UsageLedger struct {
+	mu      sync.Mutex
+	entries []*UsageEntry
+}
+
+func NewUsageLedger() *UsageLedger {
+	return &UsageLedger{}
+}
+
+func (l *UsageLedger) add(purpose, model string, usage Usage) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for _, entry := range l.entries {
+		if entry.Purpose == purpose && entry.Model == model {
+			entry.InputTokens += int64(usage.InputTokens)
+			entry.OutputTokens += int64(usage.OutputTokens)
+			entry.Calls++
+			return
+		}
+	}
+
+	l.entries = append(l.entries, &UsageEntry{
+		Purpose:      purpose,
+		Model:        model,
+		InputTokens:  int64(usage.InputTokens),
+		OutputTokens: int64(usage.OutputTokens),
+		Calls:        1,
+	})
+}
+
+// Entries returns the totals in the order the purposes and models were first used
+func (l *UsageLedger) Entries() []UsageEntry {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	entries := make([]UsageEntry, 0, len(l.entries))
+	for _, entry := range l.entries {
+		entries = append(entries, *entry)
+	}
+	return entries
+}
+
+// usageProvider records the usage of every successful call of another provider
+type usageProvider struct {
+	Provider
+	ledger  *UsageLedger
+	purpose string
+}
+
+// NewUsageProvider wraps inner so that the usage of its calls is added to the
+// ledger under purpose. Failed calls report no usage and are not counted.
+func NewUsageProvider(inner Provider, ledger *UsageLedger, purpose string) Provider {
+	return &usageProvider{Provider: inner, ledger: ledger, purpose: purpose}
+}
+
+func (p *usageProvider) Generate(ctx context.Context, req *Request) (*Response, error) {
+	res, err := p.Provider.Generate(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	p.ledger.add(p.purpose, p.Name()+":"+req.Model, res.Usage)
+	return res, nil
+}
@@ -0,0 +1,99 @@
+//go:build cgo
+
+package validation
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"path"
+	"strings"
+
+	sitter "github.com/smacker/go-tree-sitter"
+	"github.com/smacker/go-tree-sitter/javascript"
+	"github.com/smacker/go-tree-sitter/python"
+	"github.com/smacker/go-tree-sitter/typescript/tsx"
+	"github.com/smacker/go-tree-sitter/typescript/typescript"
+)
+
+// grammars maps file extensions to the embedded tree-sitter grammars
+var grammars = map[string]*sitter.Language{
+	".py":  python.GetLanguage(),
+	".js":  javascript.GetLanguage(),
+	".jsx": javascript.GetLanguage(),
+	".mjs": javascript.GetLanguage(),
+	".cjs": javascript.GetLanguage(),
+	".ts":  typescript.GetLanguage(),
+	".mts": typescript.GetLanguage(),
+	".cts": typescript.GetLanguage(),
+	".tsx": tsx.GetLanguage(),
+}
+
+func checkTreeSitter(filePath, code string) ([]Diagnostic, bool) {
+	grammar, ok := grammars[path.Ext(filePath)]
+	if !ok {
+		return nil, false
+	}
+
+	parser := sitter.NewParser()
+	defer parser.Close()
+	parser.SetLanguage(grammar)
+
+	source := []byte(code)
+	tree, err := parser.ParseCtx(context.Background(), nil, source)
+	if err != nil {
+		log.Printf("Unable to parse %s: %v", filePath, err)
+		return nil, false
+	}
+	defer tree.Close()
+
+	root := tree.RootNode()
+	if !root.HasError() {
+		return nil, true
+	}
+
+	var diagnostics []Diagnostic
+	collectErrors(root, source, &diagnostics)
+	if len(diagnostics) == 0 {
+		diagnostics = append(diagnostics, Diagnostic{Line: 1, Column: 1, Message: "syntax error"})
+	}
+	return diagnostics, true
+}
+
+// collectErrors walks the subtrees holding errors and reports the ERROR and
+// MISSING nodes, without descending into an ERROR node already reported
+func collectErrors(node *sitter.Node, source []byte, diagnostics *[]Diagnostic) {
+	if len(*diagnostics) == maxDiagnostics {
+		return
+	}
+
+	start := node.StartPoint()
+	at := Diagnostic{Line: int(start.Row) + 1, Column: int(start.Column) + 1}
+
+	switch {
+	case node.IsMissing():
+		at.Message = fmt.Sprintf("missing %q", node.Type())
+		*diagnostics = append(*diagnostics, at)
+		return
+
+	case node.IsError():
+		at.Message = fmt.Sprintf("unexpected %q", excerpt(node.Content(source)))
+		*diagnostics = append(*diagnostics, at)
+		return
+	}
+
+	for i := 0; i < int(node.ChildCount()); i++ {
+		if child := node.Child(i); child.HasError() || child.IsMissing() {
+			collectErrors(child, source, diagnostics)
+		}
+	}
+}
+
+// excerpt shortens the text of an ERROR node to its first line
+func excerpt(text string) string {
+	text, _, _ = strings.Cut(strings.TrimSpace(text), "\n")
+	if len(text) > 40 {
+		text = text[:40] + "..."
+	}
+	return text
+}
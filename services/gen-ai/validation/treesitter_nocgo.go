@@ -0,0 +1,8 @@
+//go:build !cgo
+
+package validation
+
+// Without cgo the tree-sitter grammars are not available, so only Go is checked
+func checkTreeSitter(filePath, code string) ([]Diagnostic, bool) {
+	return nil, false
+}
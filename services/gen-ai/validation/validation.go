@@ -0,0 +1,63 @@
+package validation
+
+import (
+	"errors"
+	"fmt"
+	"go/parser"
+	"go/scanner"
+	"go/token"
+	"path"
+	"strings"
+)
+
+// maxDiagnostics bounds what is reported per file, later errors are mostly noise
+const maxDiagnostics = 10
+
+// Diagnostic is one syntax error found in a generated file
+type Diagnostic struct {
+	Line, Column int
+	Message      string
+}
+
+func (d Diagnostic) String() string {
+	return fmt.Sprintf("line %d, column %d: %s", d.Line, d.Column, d.Message)
+}
+
+// Check parses the code of a test file and returns its syntax errors. Languages
+// without a parser return no diagnostics and checked set to false.
+func Check(filePath, code string) (diagnostics []Diagnostic, checked bool) {
+	if path.Ext(filePath) == ".go" {
+		return checkGo(filePath, code), true
+	}
+	return checkTreeSitter(filePath, code)
+}
+
+// Summary renders the diagnostics one per line
+func Summary(diagnostics []Diagnostic) string {
+	lines := make([]string, 0, len(diagnostics))
+	for _, d := range diagnostics {
+		lines = append(lines, d.String())
+	}
+	return strings.Join(lines, "\n")
+}
+
+func checkGo(filePath, code string) []Diagnostic {
+	_, err := parser.ParseFile(token.NewFileSet(), filePath, code, parser.AllErrors)
+	if err == nil {
+		return nil
+	}
+
+	var list scanner.ErrorList
+	if !errors.As(err, &list) {
+		return []Diagnostic{{Line: 1, Column: 1, Message: err.Error()}}
+	}
+
+	var diagnostics []Diagnostic
+	for _, e := range list {
+		if len(diagnostics) == maxDiagnostics {
+			break
+		}
+		diagnostics = append(diagnostics, Diagnostic{Line: e.Pos.Line, Column: e.Pos.Column, Message: e.Msg})
+	}
+	return diagnostics
+}
@@ -18,7 +18,7 @@ func getDatabaseURL() string {
 func GetContextAndTestsFromDatabase(key string) (*pb.CachedContents, error) {
 	conn, err := getGrpcConnection(getDatabaseURL())
 	if err != nil {
-		return nil, err
+		return nil, translate("database", err)
 	}
 	defer conn.Close()
 
@@ -29,16 +29,16 @@ func GetContextAndTestsFromDatabase(key string) (*pb.CachedContents, error) {
 
 	res, err := client.Get(ctx, &pb.KeyType{Key: key})
 	if err != nil {
-		return nil, err
+		return nil, translate("database", err)
 	}
 
 	return res, nil
 }
 
-func SetContextAndTestsToDatabase(key string, ctx []*pb.SourceFilePayload, tests []*pb.TestFilePayload) (bool, error) {
+func SetContextAndTestsToDatabase(key string, val *pb.CachedContents) (bool, error) {
 	conn, err := getGrpcConnection(getDatabaseURL())
 	if err != nil {
-		return false, err
+		return false, translate("database", err)
 	}
 	defer conn.Close()
 
@@ -47,11 +47,9 @@ func SetContextAndTestsToDatabase(key string, ctx []*pb.SourceFilePayload, tests
 	c, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
-	val := &pb.CachedContents{Contexts: ctx, Tests: tests}
-
 	res, err := client.Set(c, &pb.KeyValType{Key: key, Value: val})
 	if err != nil {
-		return false, err
+		return false, translate("database", err)
 	}
 
 	return res.Result, nil
@@ -60,7 +58,7 @@ func SetContextAndTestsToDatabase(key string, ctx []*pb.SourceFilePayload, tests
 func DeleteContextAndTestsToDatabase(key string) (bool, error) {
 	conn, err := getGrpcConnection(getDatabaseURL())
 	if err != nil {
-		return false, err
+		return false, translate("database", err)
 	}
 	defer conn.Close()
 
@@ -71,7 +69,7 @@ func DeleteContextAndTestsToDatabase(key string) (bool, error) {
 
 	res, err := client.Delete(c, &pb.KeyType{Key: key})
 	if err != nil {
-		return false, err
+		return false, translate("database", err)
 	}
 
 	return res.Result, nil
@@ -80,7 +78,7 @@ func DeleteContextAndTestsToDatabase(key string) (bool, error) {
 func GetRetryExhaustionStatus(key string) (bool, error) {
 	conn, err := getGrpcConnection(getDatabaseURL())
 	if err != nil {
-		return false, err
+		return false, translate("database", err)
 	}
 	defer conn.Close()
 
@@ -91,8 +89,68 @@ func GetRetryExhaustionStatus(key string) (bool, error) {
 
 	res, err := client.IsRetriesExhauted(c, &pb.KeyType{Key: key})
 	if err != nil {
-		return false, err
+		return false, translate("database", err)
 	}
 
 	return res.Result, nil
 }
+
+func RecordUsageToDatabase(mergeID, repository string, usage []*pb.TokenUsage) (bool, error) {
+	conn, err := getGrpcConnection(getDatabaseURL())
+	if err != nil {
+		return false, translate("database", err)
+	}
+	defer conn.Close()
+
+	client := pb.NewDatabaseServiceClient(conn)
+
+	c, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	res, err := client.RecordUsage(c, &pb.UsageRecord{MergeId: mergeID, Repository: repository, Usage: usage})
+	if err != nil {
+		return false, translate("database", err)
+	}
+
+	return res.Result, nil
+}
+
+func GetMergeUsageFromDatabase(mergeID string) (*pb.UsageReport, error) {
+	conn, err := getGrpcConnection(getDatabaseURL())
+	if err != nil {
+		return nil, translate("database", err)
+	}
+	defer conn.Close()
+
+	client := pb.NewDatabaseServiceClient(conn)
+
+	c, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	res, err := client.GetMergeUsage(c, &pb.KeyType{Key: mergeID})
+	if err != nil {
+		return nil, translate("database", err)
+	}
+
+	return res, nil
+}
+
+func GetMonthlyUsageFromDatabase(repository, month string) (*pb.UsageReport, error) {
+	conn, err := getGrpcConnection(getDatabaseURL())
+	if err != nil {
+		return nil, translate("database", err)
+	}
+	defer conn.Close()
+
+	client := pb.NewDatabaseServiceClient(conn)
+
+	c, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	res, err := client.GetMonthlyUsage(c, &pb.MonthlyUsageRequest{Repository: repository, Month: month})
+	if err != nil {
+		return nil, translate("database", err)
+	}
+
+	return res, nil
+}
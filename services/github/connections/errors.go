@@ -0,0 +1,99 @@
+package connections
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	pb "github.com/codesourcerer-bot/proto/generated"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Errors the controllers can branch on with errors.Is. Every failed call is
+// returned as a *ServiceError matching the sentinel of its gRPC code.
+var (
+	ErrNotFound          = errors.New("not found")
+	ErrInvalidArgument   = errors.New("invalid argument")
+	ErrResourceExhausted = errors.New("resource exhausted")
+	ErrDeadlineExceeded  = errors.New("deadline exceeded")
+	ErrUnavailable       = errors.New("service unavailable")
+)
+
+var sentinels = map[codes.Code]error{
+	codes.NotFound:          ErrNotFound,
+	codes.InvalidArgument:   ErrInvalidArgument,
+	codes.ResourceExhausted: ErrResourceExhausted,
+	codes.DeadlineExceeded:  ErrDeadlineExceeded,
+	codes.Unavailable:       ErrUnavailable,
+}
+
+// ServiceError is a failed call to another service
+type ServiceError struct {
+	Service string
+	Code    codes.Code
+	Message string
+	// Reason is the machine readable reason from the error details, if any
+	Reason string
+	// Fields lists the request fields the service rejected
+	Fields []string
+	// RetryAfter is the delay the service asked for before retrying, if any
+	RetryAfter time.Duration
+	// Usage is the token usage of the model calls made before the failure
+	Usage []*pb.TokenUsage
+}
+
+func (e *ServiceError) Error() string {
+	return fmt.Sprintf("%s service: %s: %s", e.Service, e.Code, e.Message)
+}
+
+func (e *ServiceError) Is(target error) bool {
+	return sentinels[e.Code] == target
+}
+
+// IsTemporary reports whether the call may succeed when retried later
+func IsTemporary(err error) bool {
+	return errors.Is(err, ErrResourceExhausted) || errors.Is(err, ErrUnavailable) || errors.Is(err, ErrDeadlineExceeded)
+}
+
+// translate turns the status of a failed call into a *ServiceError. Errors
+// without a status are returned unchanged.
+func translate(service string, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	st, ok := status.FromError(err)
+	if !ok {
+		return err
+	}
+
+	serviceErr := &ServiceError{Service: service, Code: st.Code(), Message: st.Message()}
+
+	for _, detail := range st.Details() {
+		switch d := detail.(type) {
+		case *errdetails.ErrorInfo:
+			serviceErr.Reason = d.GetReason()
+		case *errdetails.BadRequest:
+			for _, violation := range d.GetFieldViolations() {
+				serviceErr.Fields = append(serviceErr.Fields, violation.GetField())
+			}
+		case *errdetails.RetryInfo:
+			serviceErr.RetryAfter = d.GetRetryDelay().AsDuration()
+		case *pb.UsageReport:
+			serviceErr.Usage = d.GetUsage()
+		}
+	}
+
+	return serviceErr
+}
+
+// UsageOf returns the token usage the failed call reported, if any
+func UsageOf(err error) []*pb.TokenUsage {
+	var serviceErr *ServiceError
+	if errors.As(err, &serviceErr) {
+		return serviceErr.Usage
+	}
+	return nil
+}
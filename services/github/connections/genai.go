@@ -2,6 +2,8 @@ package connections
 
 import (
 	"context"
+	"errors"
+	"io"
 	"os"
 	"time"
 
@@ -18,7 +20,7 @@ func getGenAIURL() string {
 func GetGeneratedTestsFromGenAI(payload *pb.GithubContextRequest) (*pb.GeneratedTestsResponse, error) {
 	conn, err := getGrpcConnection(getGenAIURL())
 	if err != nil {
-		return nil, err
+		return nil, translate("gen-ai", err)
 	}
 	defer conn.Close()
 
@@ -29,7 +31,7 @@ func GetGeneratedTestsFromGenAI(payload *pb.GithubContextRequest) (*pb.Generated
 
 	res, err := client.GenerateTestFiles(ctx, payload)
 	if err != nil {
-		return nil, err
+		return nil, translate("gen-ai", err)
 	}
 
 	return res, nil
@@ -38,7 +40,7 @@ func GetGeneratedTestsFromGenAI(payload *pb.GithubContextRequest) (*pb.Generated
 func GetRetriedTestsFromGenAI(payload *pb.RetryMechanismPayload) (*pb.GeneratedTestsResponse, error) {
 	conn, err := getGrpcConnection(getGenAIURL())
 	if err != nil {
-		return nil, err
+		return nil, translate("gen-ai", err)
 	}
 	defer conn.Close()
 
@@ -49,8 +51,40 @@ func GetRetriedTestsFromGenAI(payload *pb.RetryMechanismPayload) (*pb.GeneratedT
 
 	res, err := client.GenerateRetriedTestFiles(ctx, payload)
 	if err != nil {
-		return nil, err
+		return nil, translate("gen-ai", err)
 	}
 
 	return res, nil
 }
+
+// StreamGeneratedTestsFromGenAI calls onResult for the result of every source
+// file as soon as the GenAI Service sends it. The results received before an
+// error are not discarded.
+func StreamGeneratedTestsFromGenAI(payload *pb.GithubContextRequest, onResult func(*pb.TestFileResult)) error {
+	conn, err := getGrpcConnection(getGenAIURL())
+	if err != nil {
+		return translate("gen-ai", err)
+	}
+	defer conn.Close()
+
+	client := pb.NewGenAiServiceClient(conn)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	stream, err := client.StreamTestFiles(ctx, payload)
+	if err != nil {
+		return translate("gen-ai", err)
+	}
+
+	for {
+		res, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return translate("gen-ai", err)
+		}
+		onResult(res)
+	}
+}
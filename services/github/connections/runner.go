@@ -0,0 +1,36 @@
+package connections
+
+import (
+	"context"
+	"os"
+	"time"
+
+	pb "github.com/codesourcerer-bot/proto/generated"
+)
+
+// IsRunnerEnabled reports whether a runner service is configured. Generated
+// tests are only pre-run when RUNNER_SERVICE_URL is set.
+func IsRunnerEnabled() bool {
+	return os.Getenv("RUNNER_SERVICE_URL") != ""
+}
+
+func RunTestsInRunner(payload *pb.RunTestsRequest) (*pb.RunTestsResponse, error) {
+	conn, err := getGrpcConnection(os.Getenv("RUNNER_SERVICE_URL"))
+	if err != nil {
+		return nil, translate("runner", err)
+	}
+	defer conn.Close()
+
+	client := pb.NewRunnerServiceClient(conn)
+
+	// Covers the download of the repository and the runner's own timeout
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+	defer cancel()
+
+	res, err := client.RunTests(ctx, payload)
+	if err != nil {
+		return nil, translate("runner", err)
+	}
+
+	return res, nil
+}
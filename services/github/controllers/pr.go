@@ -1,17 +1,17 @@
 package controllers
 
 import (
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
 
-	pb "github.com/codesourcerer-bot/proto/generated"
-
 	"github.com/codesourcerer-bot/github/connections"
 	"github.com/codesourcerer-bot/github/lib"
 	"github.com/codesourcerer-bot/github/resolvers"
 	"github.com/codesourcerer-bot/github/utils"
 	"github.com/codesourcerer-bot/github/validators"
+	pb "github.com/codesourcerer-bot/proto/generated"
 	"github.com/gin-gonic/gin"
 )
 
@@ -33,20 +33,45 @@ func PullRequestHandler(c *gin.Context) error {
 
 	pullRequestNumber, commitSHA := prBody.GetPRInfo()
 
-	ymlConfig := lib.FetchYmlConfig(repoOwner, repoName, commitSHA)
+	// A config that cannot be read leaves the defaults and the layers before it,
+	// so only pull requests into the testing branch are told about its problems
+	ymlConfig, configErr := lib.FetchYmlConfig(repoOwner, repoName, commitSHA)
 
-	if baseBranch != ymlConfig.Configuration.TestingBranch {
+	testingBranch := ymlConfig.Configuration.TestingBranch
+	if configErr != nil && testingBranch == "" {
+		testingBranch = lib.DefaultTestingBranch
+	}
+
+	if baseBranch != testingBranch {
 		c.Status(http.StatusNoContent)
 		return nil
 	}
 
+	if configErr != nil {
+		return reportConfigError(c, configErr, repoOwner, repoName, pullRequestNumber)
+	}
+
 	prDescription, err := lib.FetchPullRequestDescription(repoOwner, repoName, pullRequestNumber)
 	if err != nil {
 		log.Printf("Unable to fetch pull request description: %v", err)
 		return fmt.Errorf("unable to fetch pull request description")
 	}
 
-	dependencies, context := utils.ParsePRDescription(prDescription)
+	directives, err := utils.ParsePRDescription(prDescription)
+	if err != nil {
+		log.Printf("Invalid directives in pull request description: %v", err)
+		comment := fmt.Sprintf("CODESOURCERER could not read the `codesourcerer` block in the description, so no tests were generated.\n\n```\n%v\n```", err)
+		if err := resolvers.CommentOnPullRequest(repoOwner, repoName, pullRequestNumber, comment); err != nil {
+			log.Printf("Unable to report directive errors: %v", err)
+		}
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "invalid directives in pull request description"})
+		return nil
+	}
+
+	if directives.Skip {
+		c.JSON(http.StatusAccepted, gin.H{"message": "test generation skipped by the pull request description"})
+		return nil
+	}
 
 	changedFiles, err := lib.FetchPullRequestFiles(repoOwner, repoName, pullRequestNumber)
 	if err != nil {
@@ -54,32 +79,95 @@ func PullRequestHandler(c *gin.Context) error {
 		return fmt.Errorf("unable to fetch changed files")
 	}
 
-	fileChan := resolvers.GetFileContents(changedFiles, repoOwner, repoName, commitSHA)
-	fileChan = resolvers.GetDependencyContents(fileChan, dependencies, repoOwner, repoName, commitSHA)
+	tree, err := lib.FetchRepositoryTree(repoOwner, repoName, commitSHA)
+	if err != nil {
+		log.Printf("Unable to fetch repository tree, nested configs and imports may be missed: %v", err)
+	}
 
-	mergeID := fmt.Sprintf("merge_%s_%d", commitSHA, pullRequestNumber)
-	genConfig := lib.GetGenerationOptions(ymlConfig)
-	payload := pb.GithubContextRequest{
-		MergeId: mergeID,
-		Context: context,
-		Config:  genConfig,
+	nestedConfigs, err := lib.FetchNestedConfigs(repoOwner, repoName, commitSHA, tree)
+	if err != nil {
+		log.Printf("Unable to fetch nested configuration: %v", err)
+		return fmt.Errorf("unable to fetch nested configuration")
+	}
+
+	groups, err := resolvers.GroupFilesByConfig(ymlConfig, nestedConfigs, changedFiles)
+	if err != nil {
+		return reportConfigError(c, err, repoOwner, repoName, pullRequestNumber)
+	}
+
+	pathFilter := resolvers.GetPathFilter(repoOwner, repoName, commitSHA)
+
+	var activeGroups []*resolvers.ConfigGroup
+	for _, group := range groups {
+		groupFilter := pathFilter.WithGlobs(group.Config.Include, group.Config.Exclude)
+		group.Files = resolvers.FilterChangedFiles(group.Files, func(filePath string) bool {
+			return groupFilter.ShouldProcess(filePath) && directives.ShouldProcess(filePath)
+		})
+		if len(group.Files) > 0 {
+			activeGroups = append(activeGroups, group)
+		}
 	}
 
-	for f := range fileChan {
-		payload.Files = append(payload.Files, f)
+	detector := resolvers.NewFrameworkDetector(repoOwner, repoName, commitSHA, tree)
+	activeGroups = resolvers.SplitGroupsByFramework(activeGroups, detector)
+
+	if len(activeGroups) == 0 {
+		c.JSON(http.StatusAccepted, gin.H{"message": "no files left to generate tests for"})
+		return nil
 	}
 
-	generatedTests, err := connections.GetGeneratedTestsFromGenAI(&payload)
+	store := resolvers.NewDependencyStore(repoOwner, repoName, commitSHA, tree, ymlConfig.Dependencies.MaxDepth, ymlConfig.Dependencies.MaxBytes, !ymlConfig.Dependencies.WholeFiles)
+
+	mergeID := fmt.Sprintf("merge_%s_%d", commitSHA, pullRequestNumber)
+	generatedTests, contexts, rejected, err := resolvers.GenerateTestsForGroups(repoOwner, repoName, commitSHA, mergeID, directives, store, activeGroups)
 	if err != nil {
-		log.Printf("Error sending payload to GenAI Service: %v", err)
-		return fmt.Errorf("error forwarding payload to GenAI Service")
+		resolvers.RecordUsage(mergeID, repoOwner, repoName, generatedTests.GetUsage())
+		return reportServiceError(c, err, repoOwner, repoName, pullRequestNumber)
+	}
+
+	if len(generatedTests.GetTests()) == 0 {
+		resolvers.RecordUsage(mergeID, repoOwner, repoName, generatedTests.GetUsage())
+		summary := &resolvers.PullRequestSummary{Rejected: rejected, Skipped: generatedTests.GetSkippedFiles()}
+		if err := resolvers.CommentOnPullRequest(repoOwner, repoName, pullRequestNumber, "CODESOURCERER could not generate any tests.\n"+summary.Problems()); err != nil {
+			log.Printf("Unable to report missing tests: %v", err)
+		}
+		c.JSON(http.StatusAccepted, gin.H{"message": "no tests were generated"})
+		return nil
+	}
+
+	deps := store.GetPayloads(contexts)
+
+	// Pre-run before caching so the cache holds the tests that are pushed
+	var localRuns []resolvers.LocalRun
+	if connections.IsRunnerEnabled() {
+		localRuns = resolvers.PreRunTests(repoOwner, repoName, commitSHA, activeGroups, contexts, deps, generatedTests)
 	}
 
+	resolvers.RecordUsage(mergeID, repoOwner, repoName, generatedTests.GetUsage())
+
 	newBranch := utils.GetRandomBranch()
 
-	cacheResult := resolvers.CachePullRequest(ymlConfig.Caching.Enabled, repoOwner, repoName, newBranch, payload.GetFiles(), generatedTests.GetTests())
+	cacheResult := resolvers.CachePullRequest(ymlConfig.Caching.Enabled, repoOwner, repoName, newBranch, &pb.CachedContents{
+		Contexts:     contexts,
+		Tests:        generatedTests.GetTests(),
+		Dependencies: deps,
+		MergeId:      mergeID,
+		Config:       resolvers.RetryConfig(ymlConfig, activeGroups),
+	})
+
+	summary := &resolvers.PullRequestSummary{
+		CacheResult:   cacheResult,
+		Groups:        activeGroups,
+		Rejected:      rejected,
+		Skipped:       generatedTests.GetSkippedFiles(),
+		Model:         generatedTests.GetModel(),
+		PromptVersion: generatedTests.GetPromptVersion(),
+		LocalRuns:     localRuns,
+		Tests:         generatedTests.GetTests(),
+		Usage:         generatedTests.GetUsage(),
+	}
 
-	err = resolvers.PushNewBranchWithTests(repoOwner, repoName, ymlConfig.Configuration.TestingBranch, newBranch, cacheResult, generatedTests)
+	err = resolvers.PushNewBranchWithTests(repoOwner, repoName, ymlConfig.Configuration.TestingBranch, newBranch, summary.Body(), generatedTests)
 	if err != nil {
 		log.Printf("Error finalizing: %v", err)
 		return fmt.Errorf("error finalizing")
@@ -90,3 +178,41 @@ func PullRequestHandler(c *gin.Context) error {
 	return nil
 
 }
+
+// reportConfigError comments invalid configuration on the pull request instead of generating tests
+func reportConfigError(c *gin.Context, err error, repoOwner, repoName string, pullRequestNumber int) error {
+	var configErr *lib.ConfigError
+	if !errors.As(err, &configErr) {
+		log.Printf("Unable to fetch configuration: %v", err)
+		return fmt.Errorf("unable to fetch configuration")
+	}
+
+	log.Printf("Invalid configuration: %v", configErr)
+	if err := resolvers.CommentOnPullRequest(repoOwner, repoName, pullRequestNumber, resolvers.FormatConfigError(configErr)); err != nil {
+		log.Printf("Unable to report configuration errors: %v", err)
+	}
+
+	c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "invalid configuration file"})
+	return nil
+}
+
+// reportServiceError tells the user why generation failed when waiting or
+// changing the pull request can help. Other failures are returned as is.
+func reportServiceError(c *gin.Context, err error, repoOwner, repoName string, pullRequestNumber int) error {
+	temporary := connections.IsTemporary(err)
+	if !temporary && !errors.Is(err, connections.ErrInvalidArgument) {
+		return err
+	}
+
+	log.Printf("Unable to generate tests: %v", err)
+	if err := resolvers.CommentOnPullRequest(repoOwner, repoName, pullRequestNumber, resolvers.FormatServiceError(err)); err != nil {
+		log.Printf("Unable to report generation error: %v", err)
+	}
+
+	if temporary {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "test generation is temporarily unavailable"})
+	} else {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "test generation rejected the pull request"})
+	}
+	return nil
+}
@@ -0,0 +1,113 @@
+package controllers
+
+import (
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/codesourcerer-bot/github/connections"
+	"github.com/codesourcerer-bot/github/resolvers"
+	pb "github.com/codesourcerer-bot/proto/generated"
+	"github.com/gin-gonic/gin"
+)
+
+type usageJSON struct {
+	Purpose          string `json:"purpose,omitempty"`
+	Model            string `json:"model,omitempty"`
+	PromptTokens     int64  `json:"prompt_tokens"`
+	CompletionTokens int64  `json:"completion_tokens"`
+	TotalTokens      int64  `json:"total_tokens"`
+	Calls            int64  `json:"calls"`
+}
+
+func toUsageJSON(u *pb.TokenUsage) usageJSON {
+	return usageJSON{
+		Purpose:          u.GetPurpose(),
+		Model:            u.GetModel(),
+		PromptTokens:     u.GetPromptTokens(),
+		CompletionTokens: u.GetCompletionTokens(),
+		TotalTokens:      u.GetTotalTokens(),
+		Calls:            u.GetCalls(),
+	}
+}
+
+// UsageAuth only lets requests bearing USAGE_API_TOKEN through. The usage API
+// is disabled when the token is not set.
+func UsageAuth(ctx *gin.Context) {
+	token := os.Getenv("USAGE_API_TOKEN")
+	if token == "" {
+		ctx.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": "usage API is disabled"})
+		return
+	}
+
+	bearer, ok := strings.CutPrefix(ctx.GetHeader("Authorization"), "Bearer ")
+	if !ok || subtle.ConstantTimeCompare([]byte(bearer), []byte(token)) != 1 {
+		ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "invalid usage API token"})
+		return
+	}
+
+	ctx.Next()
+}
+
+// MonthlyUsageController reports the usage of a repository in the month
+// given as YYYY-MM, the current UTC month by default
+func MonthlyUsageController(ctx *gin.Context) {
+	repository := fmt.Sprintf("%s/%s", ctx.Param("owner"), ctx.Param("repo"))
+
+	month := ctx.DefaultQuery("month", time.Now().UTC().Format("2006-01"))
+	if _, err := time.Parse("2006-01", month); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "month must be formatted as YYYY-MM"})
+		return
+	}
+
+	report, err := connections.GetMonthlyUsageFromDatabase(repository, month)
+	if err != nil {
+		usageServiceError(ctx, err)
+		return
+	}
+
+	respondWithUsage(ctx, report, gin.H{"repository": repository, "month": month})
+}
+
+// MergeUsageController reports the usage of a single merge
+func MergeUsageController(ctx *gin.Context) {
+	mergeID := ctx.Param("mergeID")
+
+	report, err := connections.GetMergeUsageFromDatabase(mergeID)
+	if errors.Is(err, connections.ErrNotFound) {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "no usage recorded for this merge"})
+		return
+	} else if err != nil {
+		usageServiceError(ctx, err)
+		return
+	}
+
+	respondWithUsage(ctx, report, gin.H{"merge_id": mergeID})
+}
+
+func respondWithUsage(ctx *gin.Context, report *pb.UsageReport, body gin.H) {
+	usage := make([]usageJSON, 0, len(report.GetUsage()))
+	for _, u := range report.GetUsage() {
+		usage = append(usage, toUsageJSON(u))
+	}
+
+	body["merges"] = report.GetMerges()
+	body["total"] = toUsageJSON(resolvers.UsageTotals(report.GetUsage()))
+	body["usage"] = usage
+	ctx.JSON(http.StatusOK, body)
+}
+
+func usageServiceError(ctx *gin.Context, err error) {
+	log.Printf("unable to fetch token usage: %v", err)
+
+	if connections.IsTemporary(err) {
+		ctx.JSON(http.StatusServiceUnavailable, gin.H{"error": "unable to fetch token usage, retry later"})
+		return
+	}
+	ctx.JSON(http.StatusInternalServerError, gin.H{"error": "unable to fetch token usage"})
+}
@@ -1,9 +1,11 @@
 package controllers
 
 import (
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
+	"strings"
 
 	"github.com/codesourcerer-bot/github/connections"
 	"github.com/codesourcerer-bot/github/lib"
@@ -47,8 +49,12 @@ func WorkflowHandler(ctx *gin.Context) error {
 		return nil
 	}
 
-	if isRetryExhausted, err := connections.GetRetryExhaustionStatus(cacheKey); err != nil {
-		return fmt.Errorf("unable to fetch retry count: %v", isRetryExhausted)
+	if isRetryExhausted, err := connections.GetRetryExhaustionStatus(cacheKey); errors.Is(err, connections.ErrNotFound) {
+		// Branches the bot did not create, or whose cache expired, have nothing to retry
+		ctx.JSON(http.StatusAccepted, gin.H{"message": "no cached tests for this branch"})
+		return nil
+	} else if err != nil {
+		return workflowServiceError(ctx, err, "unable to fetch retry count")
 	} else if isRetryExhausted {
 		ctx.JSON(http.StatusTooManyRequests, gin.H{"error": "retry has been exhausted"})
 		return nil
@@ -60,8 +66,11 @@ func WorkflowHandler(ctx *gin.Context) error {
 	}
 
 	cache, err := connections.GetContextAndTestsFromDatabase(cacheKey)
-	if err != nil {
-		return err
+	if errors.Is(err, connections.ErrNotFound) {
+		ctx.JSON(http.StatusAccepted, gin.H{"message": "no cached tests for this branch"})
+		return nil
+	} else if err != nil {
+		return workflowServiceError(ctx, err, "unable to fetch cached tests")
 	}
 
 	payload := &pb.RetryMechanismPayload{
@@ -71,11 +80,28 @@ func WorkflowHandler(ctx *gin.Context) error {
 
 	generatedTests, err := connections.GetRetriedTestsFromGenAI(payload)
 	if err != nil {
-		log.Printf("Error from GenAI Service: %v", err)
-		return fmt.Errorf("error forwarding payload to GenAI Service")
+		resolvers.RecordUsage(cache.GetMergeId(), owner, repoName, connections.UsageOf(err))
+		return workflowServiceError(ctx, err, "error forwarding payload to GenAI Service")
 	}
 
-	if ok, err := connections.SetContextAndTestsToDatabase(cacheKey, cache.GetContexts(), generatedTests.GetTests()); err != nil || !ok {
+	resolvers.RecordUsage(cache.GetMergeId(), owner, repoName, generatedTests.GetUsage())
+
+	var rejected []string
+	generatedTests.Tests, rejected = resolvers.KeepCachedPlacement(generatedTests.GetTests(), cache.GetTests())
+	if len(rejected) > 0 {
+		log.Printf("Rejected %d regenerated tests on %s: %s", len(rejected), cacheKey, strings.Join(rejected, "; "))
+		if err := resolvers.ReportRetryRejections(owner, repoName, branchName, rejected); err != nil {
+			log.Printf("Unable to report rejected regenerated tests: %v", err)
+		}
+	}
+
+	if ok, err := connections.SetContextAndTestsToDatabase(cacheKey, &pb.CachedContents{
+		Contexts:     cache.GetContexts(),
+		Tests:        generatedTests.GetTests(),
+		Dependencies: cache.GetDependencies(),
+		MergeId:      cache.GetMergeId(),
+		Config:       cache.GetConfig(),
+	}); err != nil || !ok {
 		log.Printf("unable to update cache: %v", err)
 		return fmt.Errorf("unable to update cache")
 	}
@@ -87,3 +113,15 @@ func WorkflowHandler(ctx *gin.Context) error {
 
 	return nil
 }
+
+// workflowServiceError answers 503 when a service failure is temporary, so the
+// webhook delivery can be retried later, and returns message otherwise
+func workflowServiceError(ctx *gin.Context, err error, message string) error {
+	log.Printf("%s: %v", message, err)
+
+	if connections.IsTemporary(err) {
+		ctx.JSON(http.StatusServiceUnavailable, gin.H{"error": message + ", retry later"})
+		return nil
+	}
+	return errors.New(message)
+}
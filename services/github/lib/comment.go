@@ -0,0 +1,20 @@
+package lib
+
+import (
+	"context"
+	"log"
+
+	"github.com/google/go-github/v52/github"
+)
+
+func CreateComment(client *github.Client, ctx context.Context, owner, repo string, prNumber int, body string) error {
+	_, _, err := client.Issues.CreateComment(ctx, owner, repo, prNumber, &github.IssueComment{
+		Body: github.String(body),
+	})
+	if err != nil {
+		return err
+	}
+
+	log.Printf("Comment added to pull request #%d", prNumber)
+	return nil
+}
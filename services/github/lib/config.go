@@ -1,11 +1,13 @@
 package lib
 
 import (
+	"errors"
+	"fmt"
 	"log"
+	"path"
+	"strings"
 
 	pb "github.com/codesourcerer-bot/proto/generated"
-
-	"gopkg.in/yaml.v3"
 )
 
 // YMLConfig represents the overall structure of the YAML
@@ -13,7 +15,11 @@ type YMLConfig struct {
 	Configuration ymlConfiguration  `yaml:"configuration"`
 	Environment   ymlEnvironment    `yaml:"environment"`
 	Caching       ymlCaching        `yaml:"caching"`
-	Extras        map[string]string `yml:"extras"`
+	Dependencies  ymlDependencies   `yaml:"dependencies"`
+	Model         ymlModel          `yaml:"model"`
+	Include       []string          `yaml:"include"`
+	Exclude       []string          `yaml:"exclude"`
+	Extras        map[string]string `yaml:"extras"`
 }
 
 // Configuration holds the YAML configuration fields
@@ -22,9 +28,16 @@ type ymlConfiguration struct {
 	Comments         bool   `yaml:"comments"`
 	TestingBranch    string `yaml:"testing-branch"`
 	TestingFramework string `yaml:"testing-framework"`
+	TestPlacement    string `yaml:"test-placement"`
 	WaterMark        bool   `yaml:"water-mark"`
 }
 
+// RepoTestDirectory is the test directory relative to the repository root. The
+// configured directory may start with "/", which nested configs use to mean the root.
+func (c ymlConfiguration) RepoTestDirectory() string {
+	return strings.Trim(path.Clean("/"+c.TestDirectory), "/")
+}
+
 // Environment holds environment-specific configurations
 type ymlEnvironment struct {
 	PythonVersion float32 `yaml:"python-version"`
@@ -36,46 +49,130 @@ type ymlCaching struct {
 	RedisCaching bool `yaml:"redis-caching"`
 }
 
-const configFilePath = "codesourcerer-config.yml"
+// Dependencies bounds the transitive dependency resolution
+type ymlDependencies struct {
+	MaxDepth int `yaml:"max-depth"`
+	MaxBytes int `yaml:"max-bytes"`
+	// WholeFiles sends dependencies as is instead of trimming them to the used symbols
+	WholeFiles bool `yaml:"whole-files"`
+}
+
+// Model overrides the model and sampling settings of the GenAI service. Unset
+// fields keep the service defaults, and names outside its allowlist are ignored.
+type ymlModel struct {
+	Name            string   `yaml:"name,omitempty"`
+	Temperature     *float32 `yaml:"temperature,omitempty"`
+	TopK            *int32   `yaml:"top-k,omitempty"`
+	TopP            *float32 `yaml:"top-p,omitempty"`
+	MaxOutputTokens *int32   `yaml:"max-output-tokens,omitempty"`
+}
+
+const (
+	configFilePath = "codesourcerer-config.yml"
+	orgConfigRepo  = ".github"
+)
+
+// DefaultTestingBranch is the branch whose merged pull requests generate tests
+// unless the config names another
+const DefaultTestingBranch = "testing"
 
 var defaultConfig = YMLConfig{
-	Configuration: ymlConfiguration{TestDirectory: "/tests", Comments: true, TestingBranch: "testing", TestingFramework: "pytest", WaterMark: true},
+	Configuration: ymlConfiguration{TestDirectory: "/tests", Comments: true, TestingBranch: DefaultTestingBranch, TestingFramework: "", WaterMark: true},
 	Environment:   ymlEnvironment{PythonVersion: 3.12},
 	Caching:       ymlCaching{Enabled: false, RedisCaching: false},
+	Dependencies:  ymlDependencies{MaxDepth: 2, MaxBytes: 256000},
 	Extras:        nil,
 }
 
-// FetchConfig fetches Application Config contents and returns it as a structure
-func FetchYmlConfig(owner, repo, commitSHA string) YMLConfig {
-	// Fetch the file content from GitHub
-	content, err := FetchFileFromGitHub(owner, repo, commitSHA, configFilePath)
-	if err != nil {
-		log.Printf("unable to find config file. Using the Default Configuration. Error: %v", err)
-		return defaultConfig
+// FetchYmlConfig layers the built-in defaults, the organization default from the
+// owner's .github repository and the repository's own Application Config.
+// Missing files are skipped, while invalid ones are reported as a *ConfigError.
+func FetchYmlConfig(owner, repo, commitSHA string) (YMLConfig, error) {
+	config := defaultConfig.clone()
+	var layers []configLayer
+
+	sources := []struct{ repo, ref, path string }{
+		{repo: orgConfigRepo, ref: "", path: fmt.Sprintf("%s/%s/%s", owner, orgConfigRepo, configFilePath)},
+		{repo: repo, ref: commitSHA, path: configFilePath},
 	}
+	if repo == orgConfigRepo {
+		sources = sources[1:]
+	}
+
+	for _, source := range sources {
+		// Fetch the file content from GitHub
+		content, err := FetchFileFromGitHub(owner, source.repo, source.ref, configFilePath)
+		if errors.Is(err, ErrFileNotFound) {
+			log.Printf("unable to find %s, skipping it", source.path)
+			continue
+		} else if err != nil {
+			return config, fmt.Errorf("unable to fetch %s: %v", source.path, err)
+		}
 
-	// Parse YAML content
-	var config YMLConfig
-	err = yaml.Unmarshal([]byte(content), &config)
-	if err != nil {
-		log.Printf("Failed to parse yml file. Using the Default Configuration. Error: %v", err)
-		return defaultConfig
+		layer, err := mergeConfigLayer(&config, source.path, content)
+		if err != nil {
+			return config, err
+		}
+		layers = append(layers, layer)
 	}
 
-	return config
+	if problems := validateConfig(config, layers); len(problems) > 0 {
+		return config, &ConfigError{Problems: problems}
+	}
+
+	return config, nil
+}
+
+// clone copies the config so that merging a layer into it leaves the original untouched
+func (c YMLConfig) clone() YMLConfig {
+	c.Include = append([]string(nil), c.Include...)
+	c.Exclude = append([]string(nil), c.Exclude...)
+
+	if c.Extras != nil {
+		extras := make(map[string]string, len(c.Extras))
+		for k, v := range c.Extras {
+			extras[k] = v
+		}
+		c.Extras = extras
+	}
+
+	// yaml decodes into the values behind existing pointers, so they must not be shared
+	c.Model.Temperature = clonePointer(c.Model.Temperature)
+	c.Model.TopK = clonePointer(c.Model.TopK)
+	c.Model.TopP = clonePointer(c.Model.TopP)
+	c.Model.MaxOutputTokens = clonePointer(c.Model.MaxOutputTokens)
+
+	return c
+}
+
+func clonePointer[T any](p *T) *T {
+	if p == nil {
+		return nil
+	}
+	v := *p
+	return &v
 }
 
 func GetGenerationOptions(ymlConfig YMLConfig) *pb.Configuration {
 
 	basicConfig := pb.BasicConfig{
-		TestDirectory:    ymlConfig.Configuration.TestDirectory,
+		TestDirectory:    ymlConfig.Configuration.RepoTestDirectory(),
 		Comments:         ymlConfig.Configuration.Comments,
 		TestingFramework: ymlConfig.Configuration.TestingFramework,
 		WaterMark:        ymlConfig.Configuration.WaterMark,
 	}
 
+	modelConfig := pb.ModelConfig{
+		Name:            ymlConfig.Model.Name,
+		Temperature:     ymlConfig.Model.Temperature,
+		TopK:            ymlConfig.Model.TopK,
+		TopP:            ymlConfig.Model.TopP,
+		MaxOutputTokens: ymlConfig.Model.MaxOutputTokens,
+	}
+
 	return &pb.Configuration{
 		Configuration: &basicConfig,
 		Extras:        ymlConfig.Extras,
+		Model:         &modelConfig,
 	}
 }
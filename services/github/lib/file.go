@@ -2,6 +2,7 @@ package lib
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"log"
@@ -12,6 +13,9 @@ import (
 	"github.com/google/go-github/v52/github"
 )
 
+// ErrFileNotFound is returned when the requested file does not exist at the given ref
+var ErrFileNotFound = errors.New("file not found")
+
 func CreateFiles(client *github.Client, ctx context.Context, owner, repo, branch, filePath, content string) error {
 
 	botEmail := os.Getenv("BOT_EMAIL")
@@ -36,7 +40,11 @@ func CreateFiles(client *github.Client, ctx context.Context, owner, repo, branch
 
 func FetchFileFromGitHub(owner, repo, commitSHA, filePath string) (string, error) {
 
-	url := fmt.Sprintf("https://api.github.com/repos/%s/%s/contents/%s?ref=%s", owner, repo, filePath, commitSHA)
+	// An empty ref reads from the repository's default branch
+	url := fmt.Sprintf("https://api.github.com/repos/%s/%s/contents/%s", owner, repo, filePath)
+	if commitSHA != "" {
+		url += "?ref=" + commitSHA
+	}
 
 	req, _ := http.NewRequest("GET", url, nil)
 	configureRawHeaders(req)
@@ -49,6 +57,10 @@ func FetchFileFromGitHub(owner, repo, commitSHA, filePath string) (string, error
 	}
 	defer resp.Body.Close()
 
+	if resp.StatusCode == http.StatusNotFound {
+		return "", fmt.Errorf("%w: %s", ErrFileNotFound, filePath)
+	}
+
 	if resp.StatusCode != http.StatusOK {
 		return "", fmt.Errorf("GitHub API responded with status: %d", resp.StatusCode)
 	}
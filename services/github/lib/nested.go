@@ -0,0 +1,90 @@
+package lib
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+)
+
+// FetchNestedConfigs fetches every Application Config below the repository root, keyed by its directory
+func FetchNestedConfigs(owner, repo, commitSHA string, tree []string) (map[string]string, error) {
+	nested := make(map[string]string)
+
+	for _, p := range tree {
+		dir := path.Dir(p)
+		if path.Base(p) != configFilePath || dir == "." {
+			continue
+		}
+
+		content, err := FetchFileFromGitHub(owner, repo, commitSHA, p)
+		if errors.Is(err, ErrFileNotFound) {
+			continue
+		} else if err != nil {
+			return nil, fmt.Errorf("unable to fetch %s: %v", p, err)
+		}
+
+		nested[dir] = content
+	}
+
+	return nested, nil
+}
+
+// ApplyNestedConfigs layers the configs of every ancestor directory of the file
+// over the root config, from the shallowest to the deepest. It returns the
+// effective config along with the directories that contributed to it.
+// Globs and a relative test-directory in a nested config are resolved against its own directory.
+func ApplyNestedConfigs(root YMLConfig, nested map[string]string, filePath string) (YMLConfig, []string, error) {
+	config := root.clone()
+	var layers []configLayer
+	var dirs []string
+
+	for _, dir := range ancestorDirs(filePath) {
+		content, ok := nested[dir]
+		if !ok {
+			continue
+		}
+
+		configPath := path.Join(dir, configFilePath)
+		layer, err := mergeConfigLayer(&config, configPath, content)
+		if err != nil {
+			return config, nil, err
+		}
+
+		if findLine(layer.document, "include") > 0 {
+			config.Include = scopeGlobs(dir, config.Include)
+		}
+		if findLine(layer.document, "exclude") > 0 {
+			config.Exclude = scopeGlobs(dir, config.Exclude)
+		}
+		if findLine(layer.document, "configuration", "test-directory") > 0 && !strings.HasPrefix(config.Configuration.TestDirectory, "/") {
+			config.Configuration.TestDirectory = "/" + path.Join(dir, config.Configuration.TestDirectory)
+		}
+
+		layers = append(layers, layer)
+		dirs = append(dirs, dir)
+	}
+
+	if problems := validateConfig(config, layers); len(problems) > 0 {
+		return config, nil, &ConfigError{Problems: problems}
+	}
+
+	return config, dirs, nil
+}
+
+// ancestorDirs lists the directories containing the file, starting below the repository root
+func ancestorDirs(filePath string) []string {
+	var dirs []string
+	for dir := path.Dir(filePath); dir != "." && dir != "/"; dir = path.Dir(dir) {
+		dirs = append([]string{dir}, dirs...)
+	}
+	return dirs
+}
+
+func scopeGlobs(dir string, globs []string) []string {
+	scoped := make([]string, 0, len(globs))
+	for _, glob := range globs {
+		scoped = append(scoped, path.Join(dir, glob))
+	}
+	return scoped
+}
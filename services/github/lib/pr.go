@@ -2,6 +2,7 @@ package lib
 
 import (
 	"context"
+	"fmt"
 	"log"
 
 	"github.com/google/go-github/v52/github"
@@ -24,3 +25,19 @@ func CreatePR(client *github.Client, ctx context.Context, owner, repo, title, he
 	log.Println("Pull Request created:", title)
 	return nil
 }
+
+// FindPullRequestNumber returns the open pull request whose head is branch
+func FindPullRequestNumber(client *github.Client, ctx context.Context, owner, repo, branch string) (int, error) {
+	prs, _, err := client.PullRequests.List(ctx, owner, repo, &github.PullRequestListOptions{
+		State: "open",
+		Head:  owner + ":" + branch,
+	})
+	if err != nil {
+		return 0, err
+	}
+	if len(prs) == 0 {
+		return 0, fmt.Errorf("no open pull request for branch %s", branch)
+	}
+
+	return prs[0].GetNumber(), nil
+}
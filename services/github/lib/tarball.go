@@ -0,0 +1,24 @@
+package lib
+
+import (
+	"log"
+
+	"github.com/google/go-github/v52/github"
+)
+
+// FetchTarballURL returns a short-lived link to the gzipped tarball of the
+// repository at sha, which the runner service can download without credentials
+func FetchTarballURL(owner, repo, sha string) (string, error) {
+	client, ctx, err := GetClient()
+	if err != nil {
+		return "", err
+	}
+
+	link, _, err := client.Repositories.GetArchiveLink(ctx, owner, repo, github.Tarball, &github.RepositoryContentGetOptions{Ref: sha}, true)
+	if err != nil {
+		log.Printf("Unable to fetch tarball link for %s/%s@%s: %v", owner, repo, sha, err)
+		return "", err
+	}
+
+	return link.String(), nil
+}
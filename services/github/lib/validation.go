@@ -0,0 +1,200 @@
+package lib
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// SupportedFrameworks lists the values accepted for testing-framework
+var SupportedFrameworks = []string{"pytest", "unittest", "go-test", "jest", "vitest", "mocha", "junit"}
+
+// IsAutoFramework reports whether the framework should be detected from the repository manifests
+func IsAutoFramework(framework string) bool {
+	return framework == "" || framework == "auto"
+}
+
+// ConfigError lists every problem found in the configuration files
+type ConfigError struct {
+	Problems []string
+}
+
+func (e *ConfigError) Error() string {
+	return fmt.Sprintf("invalid configuration: %s", strings.Join(e.Problems, "; "))
+}
+
+// configLayer is one parsed configuration file, kept for line number lookups
+type configLayer struct {
+	path     string
+	document *yaml.Node
+}
+
+var unknownFieldRegex = regexp.MustCompile(`field (\S+) not found in type \S+`)
+
+// mergeConfigLayer decodes a YAML layer over the given config. Keys that are
+// absent or null keep their previous values. The parsed layer is returned
+// so that later validation can point at line numbers.
+func mergeConfigLayer(config *YMLConfig, path, content string) (configLayer, error) {
+	layer := configLayer{path: path}
+
+	// Strict pass over an empty struct to report unknown keys and type errors
+	var strict YMLConfig
+	decoder := yaml.NewDecoder(strings.NewReader(content))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&strict); err != nil && !errors.Is(err, io.EOF) {
+		return layer, &ConfigError{Problems: yamlProblems(path, err)}
+	}
+
+	var root yaml.Node
+	if err := yaml.Unmarshal([]byte(content), &root); err != nil {
+		return layer, &ConfigError{Problems: yamlProblems(path, err)}
+	}
+
+	if len(root.Content) == 0 {
+		return layer, nil
+	}
+
+	layer.document = root.Content[0]
+	pruneNullValues(layer.document)
+
+	if err := layer.document.Decode(config); err != nil {
+		return layer, &ConfigError{Problems: yamlProblems(path, err)}
+	}
+
+	return layer, nil
+}
+
+// validateConfig checks the merged configuration. Each problem points at the
+// last layer that set the offending key.
+func validateConfig(config YMLConfig, layers []configLayer) []string {
+	var problems []string
+
+	report := func(message string, keys ...string) {
+		for i := len(layers) - 1; i >= 0; i-- {
+			if line := findLine(layers[i].document, keys...); line > 0 {
+				message = fmt.Sprintf("%s line %d: %s", layers[i].path, line, message)
+				break
+			}
+		}
+		problems = append(problems, message)
+	}
+
+	if config.Configuration.TestingBranch == "" {
+		report("configuration.testing-branch must not be empty", "configuration", "testing-branch")
+	}
+
+	if !isSupportedFramework(config.Configuration.TestingFramework) {
+		report(fmt.Sprintf("configuration.testing-framework %q is not one of auto, %s", config.Configuration.TestingFramework, strings.Join(SupportedFrameworks, ", ")), "configuration", "testing-framework")
+	}
+
+	switch config.Configuration.TestPlacement {
+	case "", "colocated", "__tests__":
+	default:
+		report(fmt.Sprintf("configuration.test-placement %q must be colocated or __tests__", config.Configuration.TestPlacement), "configuration", "test-placement")
+	}
+
+	if t := config.Model.Temperature; t != nil && (*t < 0 || *t > 2) {
+		report("model.temperature must be between 0 and 2", "model", "temperature")
+	}
+
+	if k := config.Model.TopK; k != nil && *k < 1 {
+		report("model.top-k must be at least 1", "model", "top-k")
+	}
+
+	if p := config.Model.TopP; p != nil && (*p <= 0 || *p > 1) {
+		report("model.top-p must be greater than 0 and at most 1", "model", "top-p")
+	}
+
+	if n := config.Model.MaxOutputTokens; n != nil && *n < 1 {
+		report("model.max-output-tokens must be at least 1", "model", "max-output-tokens")
+	}
+
+	if config.Dependencies.MaxDepth < 1 {
+		report("dependencies.max-depth must be at least 1", "dependencies", "max-depth")
+	}
+
+	if config.Dependencies.MaxBytes < 1 {
+		report("dependencies.max-bytes must be at least 1", "dependencies", "max-bytes")
+	}
+
+	return problems
+}
+
+func isSupportedFramework(framework string) bool {
+	if IsAutoFramework(framework) {
+		return true
+	}
+	for _, f := range SupportedFrameworks {
+		if f == framework {
+			return true
+		}
+	}
+	return false
+}
+
+// yamlProblems splits a yaml error into one readable problem per line
+func yamlProblems(path string, err error) []string {
+	var typeErr *yaml.TypeError
+	if !errors.As(err, &typeErr) {
+		return []string{fmt.Sprintf("%s %s", path, strings.TrimPrefix(err.Error(), "yaml: "))}
+	}
+
+	problems := make([]string, 0, len(typeErr.Errors))
+	for _, problem := range typeErr.Errors {
+		problem = unknownFieldRegex.ReplaceAllString(problem, "unknown key $1")
+		problems = append(problems, fmt.Sprintf("%s %s", path, problem))
+	}
+	return problems
+}
+
+// findLine returns the line of the key at the given path, or 0 when it is absent
+func findLine(node *yaml.Node, keys ...string) int {
+	if node == nil {
+		return 0
+	}
+
+	line := 0
+	for _, key := range keys {
+		if node.Kind != yaml.MappingNode {
+			return 0
+		}
+
+		found := false
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if node.Content[i].Value == key {
+				line = node.Content[i].Line
+				node = node.Content[i+1]
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return 0
+		}
+	}
+
+	return line
+}
+
+// pruneNullValues drops null entries from mappings so they do not reset defaults
+func pruneNullValues(node *yaml.Node) {
+	if node.Kind != yaml.MappingNode {
+		return
+	}
+
+	content := node.Content[:0]
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		if value.Kind == yaml.ScalarNode && value.Tag == "!!null" {
+			continue
+		}
+		pruneNullValues(value)
+		content = append(content, key, value)
+	}
+	node.Content = content
+}
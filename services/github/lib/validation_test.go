@@ -0,0 +1,94 @@
+package lib
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMergeConfigLayer(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		// edit turns the default config into the expected merged config
+		edit     func(c *YMLConfig)
+		problems []string
+	}{
+		{
+			name:    "empty file keeps the defaults",
+			content: "",
+			edit:    func(c *YMLConfig) {},
+		},
+		{
+			name:    "set keys override the defaults and absent keys keep them",
+			content: "configuration:\n  testing-framework: jest\n  comments: false\n",
+			edit: func(c *YMLConfig) {
+				c.Configuration.TestingFramework = "jest"
+				c.Configuration.Comments = false
+			},
+		},
+		{
+			name:    "null values keep the defaults",
+			content: "configuration:\n  testing-branch: ~\n  test-directory: spec\ndependencies: null\n",
+			edit:    func(c *YMLConfig) { c.Configuration.TestDirectory = "spec" },
+		},
+		{
+			name:    "lists and maps are read",
+			content: "include:\n  - src/**\nextras:\n  style: short\nmodel:\n  temperature: 0.5\n",
+			edit: func(c *YMLConfig) {
+				temperature := float32(0.5)
+				c.Include = []string{"src/**"}
+				c.Extras = map[string]string{"style": "short"}
+				c.Model.Temperature = &temperature
+			},
+		},
+		{
+			name:     "unknown keys are reported with their line",
+			content:  "configuration:\n  test-dir: spec\n",
+			problems: []string{"layer.yml line 2: unknown key test-dir"},
+		},
+		{
+			name:     "type errors are reported",
+			content:  "dependencies:\n  max-depth: deep\n",
+			problems: []string{"layer.yml line 2: cannot unmarshal !!str `deep` into int"},
+		},
+		{
+			name:     "syntax errors are reported",
+			content:  "configuration: [\n",
+			problems: []string{"layer.yml"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := defaultConfig.clone()
+			_, err := mergeConfigLayer(&config, "layer.yml", tt.content)
+
+			if tt.problems != nil {
+				var configErr *ConfigError
+				if !errors.As(err, &configErr) {
+					t.Fatalf("expected a *ConfigError, got %v", err)
+				}
+				if len(configErr.Problems) != len(tt.problems) {
+					t.Fatalf("expected problems %q, got %q", tt.problems, configErr.Problems)
+				}
+				for i, problem := range tt.problems {
+					if !strings.HasPrefix(configErr.Problems[i], problem) {
+						t.Errorf("expected problem %q, got %q", problem, configErr.Problems[i])
+					}
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("mergeConfigLayer failed: %v", err)
+			}
+			want := defaultConfig.clone()
+			tt.edit(&want)
+			if !reflect.DeepEqual(config, want) {
+				t.Errorf("expected config %+v, got %+v", want, config)
+			}
+		})
+	}
+}
@@ -19,6 +19,10 @@ func main() {
 
 	router.POST("/webhook", controllers.WebhookController)
 
+	usage := router.Group("/usage", controllers.UsageAuth)
+	usage.GET("/repos/:owner/:repo", controllers.MonthlyUsageController)
+	usage.GET("/merges/:mergeID", controllers.MergeUsageController)
+
 	// Test Routes. Need to be removed later
 	router.GET("/testsend", partials.TestSendPayload) // test route for payload generation
 	router.GET("/testfinalizer", partials.TestFinalize)
@@ -58,7 +58,7 @@ func TestSendPayload(c *gin.Context) {
 	samplePayload := pb.GithubContextRequest{
 		MergeId: "merge_uvw456rst789xyz123abc890klm567def234_107",
 		Config: &pb.Configuration{
-			Configuration: &pb.BasicConfig{TestDirectory: "/tests", Comments: true, TestingFramework: "pytest", WaterMark: true},
+			Configuration: &pb.BasicConfig{TestDirectory: "tests", Comments: true, TestingFramework: "pytest", WaterMark: true},
 			Extras:        map[string]string{"indent-size": "6"},
 		},
 		Context: "This PR adds utility functions for date formatting and integrates these into a scheduling module.",
@@ -46,7 +46,9 @@ func TestFinalize(c *gin.Context) {
 	newBranch := utils.GetRandomBranch()
 
 	// Call Finalize with the token and other parameters
-	err := resolvers.PushNewBranchWithTests("puneeth072003", "testing-CS", "testing", newBranch, "DISABLED", generatedTestsResponse)
+	summary := &resolvers.PullRequestSummary{CacheResult: "DISABLED"}
+
+	err := resolvers.PushNewBranchWithTests("puneeth072003", "testing-CS", "testing", newBranch, summary.Body(), generatedTestsResponse)
 	if err != nil {
 		log.Printf("Error finalizing: %v", err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error finalizing"})
@@ -8,12 +8,12 @@ import (
 	pb "github.com/codesourcerer-bot/proto/generated"
 )
 
-func CachePullRequest(shouldCache bool, repoOwner, repoName, newBranch string, context []*pb.SourceFilePayload, tests []*pb.TestFilePayload) string {
+func CachePullRequest(shouldCache bool, repoOwner, repoName, newBranch string, cache *pb.CachedContents) string {
 
 	var cacheResult string
 	if shouldCache {
 		cacheKey := fmt.Sprintf("%s/%s/tree/%s", repoOwner, repoName, newBranch)
-		ok, err := connections.SetContextAndTestsToDatabase(cacheKey, context, tests)
+		ok, err := connections.SetContextAndTestsToDatabase(cacheKey, cache)
 		if err != nil || !ok {
 			log.Printf("unable to cache contexts and tests: %v", err)
 			cacheResult = "ERROR"
@@ -0,0 +1,306 @@
+package resolvers
+
+import (
+	"log"
+	"path"
+	"slices"
+	"sort"
+	"sync"
+
+	"github.com/codesourcerer-bot/github/lib"
+	"github.com/codesourcerer-bot/github/utils"
+
+	pb "github.com/codesourcerer-bot/proto/generated"
+)
+
+// DependencyStore fetches every dependency at most once and keeps the shared
+// contents that are sent alongside the changed files. Unless trimming is off,
+// discovered dependencies are cut down to the symbols their importers use.
+type DependencyStore struct {
+	repoOwner, repoName, commitSHA string
+	maxDepth, maxBytes             int
+	trim                           bool
+
+	tree         map[string]bool
+	treePaths    []string
+	goModules    map[string]string
+	goModuleOnce sync.Once
+
+	mu        sync.Mutex
+	contents  map[string]string
+	failed    map[string]bool
+	changed   map[string]bool
+	included  map[string]bool
+	order     []string
+	usedBytes int
+
+	importers map[string][]string
+	explicit  map[string]bool
+}
+
+func NewDependencyStore(repoOwner, repoName, commitSHA string, tree []string, maxDepth, maxBytes int, trim bool) *DependencyStore {
+	treeSet := make(map[string]bool, len(tree))
+	for _, p := range tree {
+		treeSet[p] = true
+	}
+
+	return &DependencyStore{
+		repoOwner: repoOwner,
+		repoName:  repoName,
+		commitSHA: commitSHA,
+		maxDepth:  maxDepth,
+		maxBytes:  maxBytes,
+		trim:      trim,
+		tree:      treeSet,
+		treePaths: tree,
+		contents:  make(map[string]string),
+		failed:    make(map[string]bool),
+		changed:   make(map[string]bool),
+		included:  make(map[string]bool),
+		importers: make(map[string][]string),
+		explicit:  make(map[string]bool),
+	}
+}
+
+// MarkChanged records the files changed in the PR before any import is walked,
+// so that a changed file imported by another is never charged as a dependency
+func (s *DependencyStore) MarkChanged(paths []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, p := range paths {
+		s.changed[p] = true
+	}
+}
+
+// Resolve walks the import graph of a changed file breadth first and returns
+// the names of every dependency that fits within the depth and byte budgets.
+// Explicit dependencies may be globs, which are matched against the repository tree.
+func (s *DependencyStore) Resolve(f *pb.SourceFilePayload, explicit []string) []string {
+	explicit = utils.ExpandGlobs(explicit, s.treePaths)
+
+	s.mu.Lock()
+	s.changed[f.Path] = true
+	s.contents[f.Path] = f.Content
+	// Explicit dependencies were asked for as a whole and are never trimmed
+	for _, dep := range explicit {
+		s.explicit[dep] = true
+	}
+	s.mu.Unlock()
+
+	visited := map[string]bool{f.Path: true}
+	var names []string
+
+	frontier := append(explicit, s.discover(f.Path, f.Content)...)
+
+	for depth := 1; depth <= s.maxDepth && len(frontier) > 0; depth++ {
+		var level []string
+		for _, dep := range frontier {
+			if !visited[dep] {
+				visited[dep] = true
+				level = append(level, dep)
+			}
+		}
+		sort.Strings(level)
+
+		fetched := s.fetchAll(level)
+
+		var next []string
+		for _, dep := range level {
+			content, ok := fetched[dep]
+			if !ok {
+				continue
+			}
+			if !s.include(dep) {
+				continue
+			}
+
+			names = append(names, dep)
+			if depth < s.maxDepth {
+				next = append(next, s.discover(dep, content)...)
+			}
+		}
+		frontier = next
+	}
+
+	return names
+}
+
+// GetPayloads returns the shared contents of the dependencies referenced by the
+// given files, excluding files that were changed in the PR. Trimming is final
+// only once every importer is known, so the byte budget is enforced again here
+// on the contents that are sent, closest dependencies first.
+func (s *DependencyStore) GetPayloads(files []*pb.SourceFilePayload) []*pb.SourceFileDependencyPayload {
+	referenced := make(map[string]bool)
+	for _, f := range files {
+		for _, dep := range f.GetDependencies() {
+			referenced[dep.GetName()] = true
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var deps []*pb.SourceFileDependencyPayload
+	usedBytes := 0
+	for _, name := range s.order {
+		if s.changed[name] || !referenced[name] {
+			continue
+		}
+
+		content := s.trimmed(name)
+		if usedBytes+len(content) > s.maxBytes {
+			log.Printf("Leaving out dependency %s: byte budget of %d exceeded", name, s.maxBytes)
+			continue
+		}
+		usedBytes += len(content)
+
+		deps = append(deps, &pb.SourceFileDependencyPayload{
+			Name:    name,
+			Content: content,
+		})
+	}
+	return deps
+}
+
+// trimmed returns the dependency reduced to the symbols its importers reference,
+// or the whole file when it cannot be trimmed. The caller must hold s.mu.
+func (s *DependencyStore) trimmed(dep string) string {
+	content := s.contents[dep]
+	if !s.trim || s.explicit[dep] || len(s.importers[dep]) == 0 {
+		return content
+	}
+
+	used := make(map[string]bool)
+	for _, importer := range s.importers[dep] {
+		for ident := range utils.ReferencedIdentifiers(importer, s.contents[importer]) {
+			used[ident] = true
+		}
+	}
+
+	trimmed, ok := utils.TrimToSymbols(dep, content, used)
+	if !ok {
+		return content
+	}
+
+	log.Printf("Trimmed dependency %s from %d to %d bytes", dep, len(content), len(trimmed))
+	return trimmed
+}
+
+// include charges the dependency against the byte budget the first time it is
+// seen, with its size once trimmed for the importers found so far. This bounds
+// the walk, GetPayloads enforces the budget on what is finally sent.
+func (s *DependencyStore) include(dep string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.included[dep] || s.changed[dep] {
+		return true
+	}
+
+	size := len(s.trimmed(dep))
+	if s.usedBytes+size > s.maxBytes {
+		log.Printf("Skipping dependency %s: byte budget of %d exceeded", dep, s.maxBytes)
+		return false
+	}
+
+	s.usedBytes += size
+	s.included[dep] = true
+	s.order = append(s.order, dep)
+	return true
+}
+
+// fetchAll fetches the dependencies concurrently, leaving out the ones that could not be fetched
+func (s *DependencyStore) fetchAll(deps []string) map[string]string {
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	results := make(map[string]string, len(deps))
+
+	for _, dep := range deps {
+		wg.Add(1)
+
+		go func(dep string) {
+			defer wg.Done()
+
+			content, ok := s.fetch(dep)
+			if !ok {
+				return
+			}
+
+			mu.Lock()
+			results[dep] = content
+			mu.Unlock()
+		}(dep)
+	}
+
+	wg.Wait()
+	return results
+}
+
+func (s *DependencyStore) fetch(dep string) (string, bool) {
+	s.mu.Lock()
+	content, ok := s.contents[dep]
+	failed := s.failed[dep]
+	s.mu.Unlock()
+	if ok || failed {
+		return content, ok
+	}
+
+	content, err := lib.FetchFileFromGitHub(s.repoOwner, s.repoName, s.commitSHA, dep)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err != nil {
+		log.Printf("Dropping dependency %s, unable to fetch it: %v", dep, err)
+		s.failed[dep] = true
+		return "", false
+	}
+
+	log.Printf("Successfully fetched content for dependency: %s", dep)
+	s.contents[dep] = content
+	return content, true
+}
+
+func (s *DependencyStore) discover(filePath, content string) []string {
+	if len(s.tree) == 0 {
+		return nil
+	}
+
+	if path.Ext(filePath) == ".go" {
+		s.goModuleOnce.Do(s.loadGoModules)
+	}
+
+	deps := utils.ResolveImports(filePath, content, s.tree, s.goModules)
+
+	s.mu.Lock()
+	for _, dep := range deps {
+		if !slices.Contains(s.importers[dep], filePath) {
+			s.importers[dep] = append(s.importers[dep], filePath)
+		}
+	}
+	s.mu.Unlock()
+
+	return deps
+}
+
+// loadGoModules maps every go.mod in the repository to the directory it lives in
+func (s *DependencyStore) loadGoModules() {
+	s.goModules = make(map[string]string)
+
+	for p := range s.tree {
+		if path.Base(p) != "go.mod" {
+			continue
+		}
+
+		content, err := lib.FetchFileFromGitHub(s.repoOwner, s.repoName, s.commitSHA, p)
+		if err != nil {
+			log.Printf("Unable to fetch %s: %v", p, err)
+			continue
+		}
+
+		if module := utils.ParseGoModulePath(content); module != "" {
+			s.goModules[module] = path.Dir(p)
+		}
+	}
+}
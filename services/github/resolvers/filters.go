@@ -0,0 +1,33 @@
+package resolvers
+
+import (
+	"log"
+
+	"github.com/codesourcerer-bot/github/lib"
+	"github.com/codesourcerer-bot/github/utils"
+)
+
+const (
+	ignoreFilePath    = ".codesourcererignore"
+	gitAttributesPath = ".gitattributes"
+)
+
+// GetPathFilter reads the rules of .codesourcererignore and the
+// linguist-generated / linguist-vendored entries of .gitattributes
+func GetPathFilter(repoOwner, repoName, commitSHA string) *utils.PathFilter {
+	filter := &utils.PathFilter{}
+
+	if content, err := lib.FetchFileFromGitHub(repoOwner, repoName, commitSHA, gitAttributesPath); err == nil {
+		filter.Rules = append(filter.Rules, utils.ParseGitAttributes(content)...)
+	} else {
+		log.Printf("No %s found: %v", gitAttributesPath, err)
+	}
+
+	if content, err := lib.FetchFileFromGitHub(repoOwner, repoName, commitSHA, ignoreFilePath); err == nil {
+		filter.Rules = append(filter.Rules, utils.ParseIgnoreFile(content)...)
+	} else {
+		log.Printf("No %s found: %v", ignoreFilePath, err)
+	}
+
+	return filter
+}
@@ -0,0 +1,219 @@
+package resolvers
+
+import (
+	"encoding/json"
+	"log"
+	"path"
+	"strings"
+	"sync"
+
+	"github.com/codesourcerer-bot/github/lib"
+)
+
+// FrameworkDetector picks a testing framework for a file from the manifests
+// found in its directory or the closest ancestor holding one
+type FrameworkDetector struct {
+	repoOwner, repoName, commitSHA string
+	tree                           map[string]bool
+
+	mu        sync.Mutex
+	manifests map[string]string
+}
+
+func NewFrameworkDetector(repoOwner, repoName, commitSHA string, tree []string) *FrameworkDetector {
+	treeSet := make(map[string]bool, len(tree))
+	for _, p := range tree {
+		treeSet[p] = true
+	}
+
+	return &FrameworkDetector{
+		repoOwner: repoOwner,
+		repoName:  repoName,
+		commitSHA: commitSHA,
+		tree:      treeSet,
+		manifests: make(map[string]string),
+	}
+}
+
+// Detect returns the framework for the file, or an empty string for unsupported
+// languages and projects that cannot be tested
+func (d *FrameworkDetector) Detect(filePath string) string {
+	switch ext := path.Ext(filePath); ext {
+	case ".go":
+		return d.detectGo(filePath)
+	case ".py":
+		return d.detectPython(filePath)
+	case ".js", ".jsx", ".ts", ".tsx", ".mjs", ".cjs":
+		return d.detectJavaScript(filePath)
+	case ".java", ".kt":
+		return d.detectJVM(filePath)
+	}
+	return ""
+}
+
+// detectGo needs the go.mod of the module, go test cannot run outside one
+func (d *FrameworkDetector) detectGo(filePath string) string {
+	for _, dir := range searchDirs(filePath) {
+		if d.tree[path.Join(dir, "go.mod")] {
+			return "go-test"
+		}
+	}
+
+	log.Printf("No go.mod above %s", filePath)
+	return ""
+}
+
+// pythonManifests may name the test runner of a Python project
+var pythonManifests = []string{"pyproject.toml", "setup.cfg", "tox.ini", "setup.py"}
+
+// detectPython picks pytest or unittest from the closest manifest naming one.
+// Manifests naming neither are skipped, and pytest, which also runs unittest
+// suites, is the default.
+func (d *FrameworkDetector) detectPython(filePath string) string {
+	for _, dir := range searchDirs(filePath) {
+		if d.tree[path.Join(dir, "pytest.ini")] || d.tree[path.Join(dir, "conftest.py")] {
+			return "pytest"
+		}
+
+		for _, name := range pythonManifests {
+			content, ok := d.manifest(path.Join(dir, name))
+			if !ok {
+				continue
+			}
+			if strings.Contains(content, "pytest") {
+				return "pytest"
+			}
+			if strings.Contains(content, "unittest") {
+				return "unittest"
+			}
+		}
+	}
+
+	return "pytest"
+}
+
+// jvmManifests are the build files of Maven and Gradle projects
+var jvmManifests = []string{"pom.xml", "build.gradle", "build.gradle.kts"}
+
+// detectJVM reads the closest build file. JUnit is the only supported JVM
+// framework, so a build using another one, like TestNG, cannot be tested.
+func (d *FrameworkDetector) detectJVM(filePath string) string {
+	for _, dir := range searchDirs(filePath) {
+		for _, name := range jvmManifests {
+			content, ok := d.manifest(path.Join(dir, name))
+			if !ok {
+				continue
+			}
+			lower := strings.ToLower(content)
+			if strings.Contains(lower, "testng") && !strings.Contains(lower, "junit") {
+				log.Printf("%s uses TestNG, which is not supported", path.Join(dir, name))
+				return ""
+			}
+			return "junit"
+		}
+	}
+
+	log.Printf("No Maven or Gradle build above %s", filePath)
+	return ""
+}
+
+func (d *FrameworkDetector) detectJavaScript(filePath string) string {
+	for _, dir := range searchDirs(filePath) {
+		content, ok := d.manifest(path.Join(dir, "package.json"))
+		if !ok {
+			continue
+		}
+
+		var manifest struct {
+			Dependencies    map[string]string `json:"dependencies"`
+			DevDependencies map[string]string `json:"devDependencies"`
+		}
+		if err := json.Unmarshal([]byte(content), &manifest); err != nil {
+			log.Printf("Unable to parse %s: %v", path.Join(dir, "package.json"), err)
+			continue
+		}
+
+		for _, framework := range []string{"vitest", "jest", "mocha"} {
+			if _, ok := manifest.DevDependencies[framework]; ok {
+				return framework
+			}
+			if _, ok := manifest.Dependencies[framework]; ok {
+				return framework
+			}
+		}
+	}
+
+	return "jest"
+}
+
+// manifest fetches a manifest once, reporting false when it is not in the tree
+func (d *FrameworkDetector) manifest(manifestPath string) (string, bool) {
+	if !d.tree[manifestPath] {
+		return "", false
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if content, ok := d.manifests[manifestPath]; ok {
+		return content, true
+	}
+
+	content, err := lib.FetchFileFromGitHub(d.repoOwner, d.repoName, d.commitSHA, manifestPath)
+	if err != nil {
+		log.Printf("Unable to fetch %s: %v", manifestPath, err)
+		return "", false
+	}
+
+	d.manifests[manifestPath] = content
+	return content, true
+}
+
+// searchDirs lists the directories containing the file, from the closest up to the repository root
+func searchDirs(filePath string) []string {
+	var dirs []string
+	for dir := path.Dir(filePath); ; dir = path.Dir(dir) {
+		if dir == "/" {
+			dir = "."
+		}
+		dirs = append(dirs, dir)
+		if dir == "." {
+			return dirs
+		}
+	}
+}
+
+// SplitGroupsByFramework splits the groups without a configured framework by the
+// framework detected for each file. Files in unsupported languages are dropped.
+func SplitGroupsByFramework(groups []*ConfigGroup, detector *FrameworkDetector) []*ConfigGroup {
+	var split []*ConfigGroup
+
+	for _, group := range groups {
+		if !lib.IsAutoFramework(group.Config.Configuration.TestingFramework) {
+			split = append(split, group)
+			continue
+		}
+
+		byFramework := make(map[string]*ConfigGroup)
+		for _, f := range group.Files {
+			filePath := f["filename"].(string)
+
+			framework := detector.Detect(filePath)
+			if framework == "" {
+				log.Printf("No supported testing framework for %s, skipping it", filePath)
+				continue
+			}
+
+			sub, ok := byFramework[framework]
+			if !ok {
+				sub = &ConfigGroup{Config: group.Config, Dirs: group.Dirs, Detected: true}
+				sub.Config.Configuration.TestingFramework = framework
+				byFramework[framework] = sub
+				split = append(split, sub)
+			}
+			sub.Files = append(sub.Files, f)
+		}
+	}
+
+	return split
+}
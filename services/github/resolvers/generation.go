@@ -0,0 +1,116 @@
+package resolvers
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/codesourcerer-bot/github/connections"
+	"github.com/codesourcerer-bot/github/lib"
+	"github.com/codesourcerer-bot/github/utils"
+
+	pb "github.com/codesourcerer-bot/proto/generated"
+	"github.com/codesourcerer-bot/proto/tokenusage"
+)
+
+// GenerateTestsForGroups streams the tests of each config group from the GenAI
+// Service and merges them, keeping the files that completed when a stream fails.
+// It also returns every source file that was sent, for caching, and why any
+// generated test was rejected. When nothing was generated, the merged response
+// is still returned with the error, for the usage of the failed calls.
+func GenerateTestsForGroups(repoOwner, repoName, commitSHA, mergeID string, directives *utils.PRDirectives, store *DependencyStore, groups []*ConfigGroup) (*pb.GeneratedTestsResponse, []*pb.SourceFilePayload, []string, error) {
+	generatedTests := &pb.GeneratedTestsResponse{}
+	var contexts []*pb.SourceFilePayload
+	var rejected []string
+	var streamErr error
+
+	var changed []string
+	for _, group := range groups {
+		for _, f := range group.Files {
+			changed = append(changed, f["filename"].(string))
+		}
+	}
+	store.MarkChanged(changed)
+
+	for _, group := range groups {
+		if directives.Framework != "" {
+			group.Config.Configuration.TestingFramework = directives.Framework
+		}
+
+		fileChan := GetFileContents(group.Files, repoOwner, repoName, commitSHA)
+		fileChan = GetDependencyContents(fileChan, directives, store)
+
+		payload := pb.GithubContextRequest{
+			MergeId: mergeID,
+			Context: directives.Context,
+			Config:  lib.GetGenerationOptions(group.Config),
+		}
+
+		for f := range fileChan {
+			payload.Files = append(payload.Files, f)
+		}
+		payload.Dependencies = store.GetPayloads(payload.Files)
+
+		var groupTests []*pb.TestFilePayload
+		received := make(map[string]bool)
+
+		err := connections.StreamGeneratedTestsFromGenAI(&payload, func(result *pb.TestFileResult) {
+			received[result.GetPath()] = true
+			generatedTests.Usage = tokenusage.Merge(generatedTests.Usage, result.GetUsage())
+			if test := result.GetTest(); test != nil {
+				groupTests = append(groupTests, test)
+				generatedTests.Model = joinDistinct(generatedTests.Model, result.GetModel())
+				generatedTests.PromptVersion = joinDistinct(generatedTests.PromptVersion, result.GetPromptVersion())
+				return
+			}
+			generatedTests.SkippedFiles = append(generatedTests.SkippedFiles, &pb.SkippedFile{Path: result.GetPath(), Reason: result.GetError()})
+		})
+		if err != nil {
+			log.Printf("Error streaming tests for %s from GenAI Service: %v", group.Scope(), err)
+			generatedTests.Usage = tokenusage.Merge(generatedTests.Usage, connections.UsageOf(err))
+			if streamErr == nil {
+				streamErr = err
+			}
+			for _, f := range payload.Files {
+				if !received[f.GetPath()] {
+					generatedTests.SkippedFiles = append(generatedTests.SkippedFiles, &pb.SkippedFile{Path: f.GetPath(), Reason: "generation was interrupted"})
+				}
+			}
+		}
+
+		tests, groupRejected := EnforceTestPlacement(groupTests, group.Config)
+
+		generatedTests.Tests = append(generatedTests.Tests, tests...)
+		rejected = append(rejected, groupRejected...)
+		contexts = append(contexts, payload.Files...)
+	}
+
+	// Groups are placed separately, so two of them can still claim the same path
+	var duplicates []string
+	generatedTests.Tests, duplicates = RejectDuplicatePaths(generatedTests.Tests)
+	rejected = append(rejected, duplicates...)
+
+	// Partial results are kept, the run only fails when nothing was generated. The
+	// stream error is wrapped so the caller can tell a quota error from a bad request.
+	if len(generatedTests.Tests) == 0 && streamErr != nil {
+		return generatedTests, nil, nil, fmt.Errorf("error forwarding payload to GenAI Service: %w", streamErr)
+	}
+
+	return generatedTests, contexts, rejected, nil
+}
+
+// joinDistinct adds value to the comma-separated list unless it is already there
+func joinDistinct(list, value string) string {
+	if value == "" {
+		return list
+	}
+	for _, v := range strings.Split(list, ", ") {
+		if v == value {
+			return list
+		}
+	}
+	if list == "" {
+		return value
+	}
+	return list + ", " + value
+}
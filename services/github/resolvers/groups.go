@@ -0,0 +1,79 @@
+package resolvers
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/codesourcerer-bot/github/lib"
+
+	pb "github.com/codesourcerer-bot/proto/generated"
+)
+
+// ConfigGroup is a set of changed files sharing one effective configuration
+type ConfigGroup struct {
+	Config   lib.YMLConfig
+	Dirs     []string
+	Files    []map[string]interface{}
+	Detected bool
+}
+
+// RetryConfig is the configuration cached for the retries of a failed workflow.
+// The cache holds the tests of every group, so the root configuration is used,
+// with the framework the groups share or none when they differ.
+func RetryConfig(ymlConfig lib.YMLConfig, groups []*ConfigGroup) *pb.Configuration {
+	if len(groups) == 1 {
+		return lib.GetGenerationOptions(groups[0].Config)
+	}
+
+	config := lib.GetGenerationOptions(ymlConfig)
+	config.Configuration.TestingFramework = ""
+	for i, group := range groups {
+		framework := group.Config.Configuration.TestingFramework
+		if i > 0 && framework != config.Configuration.TestingFramework {
+			config.Configuration.TestingFramework = ""
+			break
+		}
+		config.Configuration.TestingFramework = framework
+	}
+	return config
+}
+
+// Scope names the nested config directories that shaped the group's configuration
+func (g *ConfigGroup) Scope() string {
+	scope := "repository root"
+	if len(g.Dirs) > 0 {
+		scope = strings.Join(g.Dirs, ", ")
+	}
+
+	if g.Detected {
+		scope += fmt.Sprintf(", detected %s", g.Config.Configuration.TestingFramework)
+	}
+	return scope
+}
+
+// GroupFilesByConfig groups the changed files by the nested configs that apply to them
+func GroupFilesByConfig(root lib.YMLConfig, nested map[string]string, changedFiles []map[string]interface{}) ([]*ConfigGroup, error) {
+	groups := make(map[string]*ConfigGroup)
+	var ordered []*ConfigGroup
+
+	for _, f := range changedFiles {
+		filePath := f["filename"].(string)
+
+		config, dirs, err := lib.ApplyNestedConfigs(root, nested, filePath)
+		if err != nil {
+			return nil, err
+		}
+
+		key := strings.Join(dirs, "\n")
+		group, ok := groups[key]
+		if !ok {
+			group = &ConfigGroup{Config: config, Dirs: dirs}
+			groups[key] = group
+			ordered = append(ordered, group)
+		}
+
+		group.Files = append(group.Files, f)
+	}
+
+	return ordered, nil
+}
@@ -2,7 +2,6 @@ package resolvers
 
 import (
 	"log"
-	"sync"
 
 	"github.com/codesourcerer-bot/github/lib"
 	"github.com/codesourcerer-bot/github/utils"
@@ -10,6 +9,20 @@ import (
 	pb "github.com/codesourcerer-bot/proto/generated"
 )
 
+// FilterChangedFiles keeps the changed files whose path is accepted by shouldProcess
+func FilterChangedFiles(changedFiles []map[string]interface{}, shouldProcess func(string) bool) []map[string]interface{} {
+	var filtered []map[string]interface{}
+	for _, f := range changedFiles {
+		filePath := f["filename"].(string)
+		if shouldProcess(filePath) {
+			filtered = append(filtered, f)
+		} else {
+			log.Printf("Skipping excluded file: %s", filePath)
+		}
+	}
+	return filtered
+}
+
 func GetFileContents(fileContents []map[string]interface{}, repoOwner, repoName, commitSHA string) <-chan *pb.SourceFilePayload {
 	outChan := make(chan *pb.SourceFilePayload)
 
@@ -36,43 +49,18 @@ func GetFileContents(fileContents []map[string]interface{}, repoOwner, repoName,
 	return outChan
 }
 
-func GetDependencyContents(fileChan <-chan *pb.SourceFilePayload, dependencies map[string][]string, repoOwner, repoName, commitSHA string) <-chan *pb.SourceFilePayload {
+func GetDependencyContents(fileChan <-chan *pb.SourceFilePayload, directives *utils.PRDirectives, store *DependencyStore) <-chan *pb.SourceFilePayload {
 	outChan := make(chan *pb.SourceFilePayload)
 
 	go func() {
 		for f := range fileChan {
-			fileDependencies := utils.FilterDependenciesForFile(f.Path, dependencies)
-			var wg sync.WaitGroup
-			depChan := make(chan *pb.SourceFileDependencyPayload, len(fileDependencies))
-
-			for _, dep := range fileDependencies {
-				wg.Add(1)
-
-				go func(channel chan<- *pb.SourceFileDependencyPayload, dep string) {
-					defer wg.Done()
-
-					depContent, err := lib.FetchFileFromGitHub(repoOwner, repoName, commitSHA, dep)
-					if err != nil {
-						log.Printf("Unable to fetch content for dependency %s: %v", dep, err)
-						depContent = "Error fetching content"
-					} else {
-						log.Printf("Successfully fetched content for dependency: %s", dep)
-					}
-
-					channel <- &pb.SourceFileDependencyPayload{
-						Name:    dep,
-						Content: depContent,
-					}
-				}(depChan, dep)
-			}
-
-			wg.Wait()
-			close(depChan)
+			fileDirective := directives.ForFile(f.Path)
+			f.Context = fileDirective.Context
 
+			// Contents are shared through the store, so each file only references its dependencies by name
 			var deps []*pb.SourceFileDependencyPayload
-
-			for d := range depChan {
-				deps = append(deps, d)
+			for _, dep := range store.Resolve(f, fileDirective.Dependencies) {
+				deps = append(deps, &pb.SourceFileDependencyPayload{Name: dep})
 			}
 
 			f.Dependencies = deps
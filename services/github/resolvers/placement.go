@@ -0,0 +1,101 @@
+package resolvers
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/codesourcerer-bot/github/lib"
+	"github.com/codesourcerer-bot/github/utils"
+
+	pb "github.com/codesourcerer-bot/proto/generated"
+)
+
+// EnforceTestPlacement rewrites the test file paths to follow the conventions of
+// the configured framework and drops the tests that cannot be placed safely.
+// The reasons for every dropped test are returned for the pull request body.
+func EnforceTestPlacement(tests []*pb.TestFilePayload, ymlConfig lib.YMLConfig) ([]*pb.TestFilePayload, []string) {
+	var placed []*pb.TestFilePayload
+	var rejected []string
+
+	configuration := ymlConfig.Configuration
+	reject := func(err error) {
+		log.Printf("Rejecting generated test: %v", err)
+		rejected = append(rejected, err.Error())
+	}
+
+	for _, test := range tests {
+		proposed, err := utils.NormalizeTestPath(test.GetTestfilepath())
+		if err != nil {
+			reject(err)
+			continue
+		}
+
+		testPath, err := utils.PlaceTestFile(configuration.TestingFramework, configuration.RepoTestDirectory(), configuration.TestPlacement, test.GetParentpath(), proposed)
+		if err != nil {
+			reject(err)
+			continue
+		}
+
+		if err := utils.ValidateTestPath(configuration.TestingFramework, configuration.RepoTestDirectory(), configuration.TestPlacement, test.GetParentpath(), testPath); err != nil {
+			reject(err)
+			continue
+		}
+
+		if testPath != test.GetTestfilepath() {
+			log.Printf("Moving generated test from %s to %s", test.GetTestfilepath(), testPath)
+			test.Testfilepath = testPath
+		}
+		placed = append(placed, test)
+	}
+
+	return placed, rejected
+}
+
+// KeepCachedPlacement pins regenerated tests to the paths of the cached tests covering the same file
+func KeepCachedPlacement(tests, cached []*pb.TestFilePayload) ([]*pb.TestFilePayload, []string) {
+	paths := make(map[string]string, len(cached))
+	for _, test := range cached {
+		paths[test.GetParentpath()] = test.GetTestfilepath()
+	}
+
+	var placed []*pb.TestFilePayload
+	var rejected []string
+
+	for _, test := range tests {
+		testPath, ok := paths[test.GetParentpath()]
+		if !ok {
+			message := fmt.Sprintf("regenerated test %q covers %q, which has no earlier test", test.GetTestfilepath(), test.GetParentpath())
+			log.Printf("Rejecting regenerated test: %s", message)
+			rejected = append(rejected, message)
+			continue
+		}
+
+		test.Testfilepath = testPath
+		placed = append(placed, test)
+	}
+
+	return placed, rejected
+}
+
+// RejectDuplicatePaths keeps the first test for every test file path. Later
+// tests placed on the same path would overwrite it, so they are rejected.
+func RejectDuplicatePaths(tests []*pb.TestFilePayload) ([]*pb.TestFilePayload, []string) {
+	owners := make(map[string]string, len(tests))
+
+	var kept []*pb.TestFilePayload
+	var rejected []string
+
+	for _, test := range tests {
+		if owner, ok := owners[test.GetTestfilepath()]; ok {
+			message := fmt.Sprintf("test %q for %q is placed on the same path as the test for %q", test.GetTestfilepath(), test.GetParentpath(), owner)
+			log.Printf("Rejecting generated test: %s", message)
+			rejected = append(rejected, message)
+			continue
+		}
+
+		owners[test.GetTestfilepath()] = test.GetParentpath()
+		kept = append(kept, test)
+	}
+
+	return kept, rejected
+}
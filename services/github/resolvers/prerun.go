@@ -0,0 +1,231 @@
+package resolvers
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/codesourcerer-bot/github/connections"
+	"github.com/codesourcerer-bot/github/lib"
+
+	pb "github.com/codesourcerer-bot/proto/generated"
+	"github.com/codesourcerer-bot/proto/tokenusage"
+)
+
+const defaultLocalRunAttempts = 2
+
+// LocalRun is the outcome of pre-running the tests of one framework in the runner service
+type LocalRun struct {
+	Framework string
+	Attempts  int
+	Passed    bool
+	TimedOut  bool
+	// Error is set when the tests could not be run at all
+	Error string
+}
+
+// localRunAttempts reads LOCAL_RUN_ATTEMPTS, the number of runs per framework
+// including the ones after regeneration
+func localRunAttempts() int {
+	if attempts, err := strconv.Atoi(os.Getenv("LOCAL_RUN_ATTEMPTS")); err == nil && attempts > 0 {
+		return attempts
+	}
+	return defaultLocalRunAttempts
+}
+
+// PreRunTests runs the generated tests of every framework against the merge
+// commit in the runner service. Failing tests are regenerated from the logs,
+// like a failed workflow would be, until they pass or the attempts run out.
+// The tests and token usage are updated in place and pushed whatever the outcome.
+func PreRunTests(owner, repo, sha string, groups []*ConfigGroup, contexts []*pb.SourceFilePayload, deps []*pb.SourceFileDependencyPayload, generated *pb.GeneratedTestsResponse) []LocalRun {
+	tarballURL, err := lib.FetchTarballURL(owner, repo, sha)
+	if err != nil {
+		return []LocalRun{{Framework: "all", Error: "the repository tarball could not be fetched"}}
+	}
+
+	frameworks := make(map[string]string)
+	distinct := make(map[string]bool)
+	configs := make(map[string]*pb.Configuration)
+	for _, group := range groups {
+		framework := group.Config.Configuration.TestingFramework
+		distinct[framework] = true
+		if _, ok := configs[framework]; !ok {
+			configs[framework] = lib.GetGenerationOptions(group.Config)
+		}
+		for _, f := range group.Files {
+			frameworks[normalizeParentPath(f["filename"].(string))] = framework
+		}
+	}
+
+	// A test whose parent path matches no changed file can only be placed when
+	// every group uses the same framework. Otherwise it is pushed without a pre-run.
+	fallback := ""
+	if len(distinct) == 1 {
+		for framework := range distinct {
+			fallback = framework
+		}
+	}
+
+	partitions := make(map[string][]*pb.TestFilePayload)
+	var unmatched []*pb.TestFilePayload
+	for _, test := range generated.GetTests() {
+		framework, ok := frameworks[normalizeParentPath(test.GetParentpath())]
+		if !ok {
+			framework = fallback
+		}
+		if framework == "" {
+			log.Printf("Not pre-running %s, %s is not a changed file", test.GetTestfilepath(), test.GetParentpath())
+			unmatched = append(unmatched, test)
+			continue
+		}
+		partitions[framework] = append(partitions[framework], test)
+	}
+
+	names := make([]string, 0, len(partitions))
+	for framework := range partitions {
+		names = append(names, framework)
+	}
+	sort.Strings(names)
+
+	attempts := localRunAttempts()
+	var runs []LocalRun
+	var kept []*pb.TestFilePayload
+
+	for _, framework := range names {
+		tests, run, usage := preRunFramework(tarballURL, framework, configs[framework], partitions[framework], contexts, deps, attempts)
+		kept = append(kept, tests...)
+		generated.Usage = tokenusage.Merge(generated.Usage, usage)
+		runs = append(runs, run)
+	}
+
+	if len(unmatched) > 0 {
+		runs = append(runs, LocalRun{Error: fmt.Sprintf("%d tests cover files that were not changed", len(unmatched))})
+		kept = append(kept, unmatched...)
+	}
+
+	generated.Tests = kept
+	return runs
+}
+
+// normalizeParentPath makes the paths of the model and of GitHub comparable
+func normalizeParentPath(p string) string {
+	return strings.TrimPrefix(path.Clean("/"+p), "/")
+}
+
+func preRunFramework(tarballURL, framework string, config *pb.Configuration, tests []*pb.TestFilePayload, contexts []*pb.SourceFilePayload, deps []*pb.SourceFileDependencyPayload, attempts int) ([]*pb.TestFilePayload, LocalRun, []*pb.TokenUsage) {
+	run := LocalRun{Framework: framework}
+	var usage []*pb.TokenUsage
+
+	for run.Attempts < attempts {
+		run.Attempts++
+
+		res, err := connections.RunTestsInRunner(&pb.RunTestsRequest{TarballUrl: tarballURL, Framework: framework, Tests: tests})
+		if err != nil {
+			log.Printf("Unable to pre-run %s tests: %v", framework, err)
+			run.Error = "the runner service could not run the tests"
+			return tests, run, usage
+		}
+
+		run.Passed, run.TimedOut = res.GetPassed(), res.GetTimedOut()
+		if run.Passed {
+			return tests, run, usage
+		}
+
+		// The tests never ran, regenerating them cannot help
+		if res.GetEnvironmentFailure() {
+			log.Printf("Pre-run of %s tests failed before testing: %s", framework, strings.Join(lastLines(res.GetLogs(), 5), " | "))
+			run.Error = "the dependencies or the build of the repository failed"
+			return tests, run, usage
+		}
+
+		if run.Attempts == attempts {
+			return tests, run, usage
+		}
+
+		log.Printf("Pre-run of %s tests failed with exit code %d, regenerating", framework, res.GetExitCode())
+
+		payload := &pb.RetryMechanismPayload{
+			Cache: &pb.CachedContents{Contexts: contextsFor(contexts, tests), Tests: tests, Dependencies: deps, Config: config},
+			Logs:  res.GetLogs(),
+		}
+
+		retried, err := connections.GetRetriedTestsFromGenAI(payload)
+		if err != nil {
+			log.Printf("Error from GenAI Service: %v", err)
+			usage = tokenusage.Merge(usage, connections.UsageOf(err))
+			run.Error = "the failing tests could not be regenerated"
+			return tests, run, usage
+		}
+
+		usage = tokenusage.Merge(usage, retried.GetUsage())
+
+		placed, _ := KeepCachedPlacement(retried.GetTests(), tests)
+		tests = replaceTests(tests, placed)
+	}
+
+	return tests, run, usage
+}
+
+// lastLines returns at most n lines from the end of logs
+func lastLines(logs []string, n int) []string {
+	if len(logs) > n {
+		return logs[len(logs)-n:]
+	}
+	return logs
+}
+
+// contextsFor keeps the source files the tests were generated for
+func contextsFor(contexts []*pb.SourceFilePayload, tests []*pb.TestFilePayload) []*pb.SourceFilePayload {
+	covered := make(map[string]bool, len(tests))
+	for _, test := range tests {
+		covered[test.GetParentpath()] = true
+	}
+
+	var kept []*pb.SourceFilePayload
+	for _, c := range contexts {
+		if covered[c.GetPath()] {
+			kept = append(kept, c)
+		}
+	}
+	return kept
+}
+
+// replaceTests swaps in the regenerated tests by path, keeping the tests the
+// model did not regenerate
+func replaceTests(tests, regenerated []*pb.TestFilePayload) []*pb.TestFilePayload {
+	byPath := make(map[string]*pb.TestFilePayload, len(regenerated))
+	for _, test := range regenerated {
+		byPath[test.GetTestfilepath()] = test
+	}
+
+	replaced := make([]*pb.TestFilePayload, 0, len(tests))
+	for _, test := range tests {
+		if r, ok := byPath[test.GetTestfilepath()]; ok {
+			test = r
+		}
+		replaced = append(replaced, test)
+	}
+	return replaced
+}
+
+// describe renders the run for the pull request summary
+func (r LocalRun) describe() string {
+	framework := r.Framework
+	if framework == "" {
+		framework = "unknown framework"
+	}
+
+	switch {
+	case r.Error != "":
+		return fmt.Sprintf("%s: not run, %s", framework, r.Error)
+	case r.Passed:
+		return fmt.Sprintf("%s: passed after %d run(s)", framework, r.Attempts)
+	case r.TimedOut:
+		return fmt.Sprintf("%s: timed out after %d run(s), pushed for the workflow to confirm", framework, r.Attempts)
+	}
+	return fmt.Sprintf("%s: still failing after %d run(s), pushed for the workflow to confirm", framework, r.Attempts)
+}
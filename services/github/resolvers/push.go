@@ -6,9 +6,10 @@ import (
 	pb "github.com/codesourcerer-bot/proto/generated"
 
 	"github.com/codesourcerer-bot/github/lib"
+	"github.com/codesourcerer-bot/github/utils"
 )
 
-func PushNewBranchWithTests(owner, repo, baseBranch, newBranch, cacheResult string, tests *pb.GeneratedTestsResponse) error {
+func PushNewBranchWithTests(owner, repo, baseBranch, newBranch, prBody string, tests *pb.GeneratedTestsResponse) error {
 
 	// Get GitHub client
 	client, ctx, err := lib.GetClient()
@@ -26,9 +27,14 @@ func PushNewBranchWithTests(owner, repo, baseBranch, newBranch, cacheResult stri
 
 	// Add the test files with content
 	for _, testFile := range tests.Tests {
+		if err := utils.CheckWritablePath(testFile.GetTestfilepath()); err != nil {
+			log.Printf("Skipping unsafe test file: %v", err)
+			continue
+		}
+
 		err = lib.CreateFiles(client, ctx, owner, repo, newBranch, testFile.GetTestfilepath(), testFile.GetCode())
 		if err != nil {
-			log.Fatalf("Error creating file %s: %v", testFile.Testfilepath, err)
+			log.Printf("Error creating file %s: %v", testFile.Testfilepath, err)
 			return err
 		}
 	}
@@ -40,21 +46,11 @@ func PushNewBranchWithTests(owner, repo, baseBranch, newBranch, cacheResult stri
 
 	defaultBranch := repoInfo.GetDefaultBranch()
 
-	prTitle := "chore: tests generated for the code added"            // hardcoded for now
-	prBody := "This is a draft PR created from the sandbox branch.\n" // hardcoded for now
-
-	switch cacheResult {
-
-	case "DONE":
-		prBody += "This PR has been cached!"
-
-	case "ERROR":
-		prBody = "This PR could not be cached!"
-	}
+	prTitle := "chore: tests generated for the code added" // hardcoded for now
 
 	err = lib.CreatePR(client, ctx, owner, repo, prTitle, newBranch, defaultBranch, prBody)
 	if err != nil {
-		log.Fatalf("Error creating draft PR: %v", err)
+		log.Printf("Error creating draft PR: %v", err)
 		return err
 	}
 
@@ -69,9 +65,14 @@ func CommitRetriedTests(owner, repo, branch string, tests *pb.GeneratedTestsResp
 	}
 
 	for _, testFile := range tests.Tests {
+		if err := utils.CheckWritablePath(testFile.GetTestfilepath()); err != nil {
+			log.Printf("Skipping unsafe test file: %v", err)
+			continue
+		}
+
 		err := lib.CreateFiles(client, ctx, owner, repo, branch, testFile.GetTestfilepath(), testFile.GetCode())
 		if err != nil {
-			log.Fatalf("Error creating file %s: %v", testFile.Testfilepath, err)
+			log.Printf("Error creating file %s: %v", testFile.Testfilepath, err)
 			return err
 		}
 	}
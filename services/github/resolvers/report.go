@@ -0,0 +1,73 @@
+package resolvers
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/codesourcerer-bot/github/connections"
+	"github.com/codesourcerer-bot/github/lib"
+)
+
+func CommentOnPullRequest(owner, repo string, prNumber int, body string) error {
+	client, ctx, err := lib.GetClient()
+	if err != nil {
+		log.Printf("Error creating client: %v", err)
+		return err
+	}
+
+	return lib.CreateComment(client, ctx, owner, repo, prNumber, body)
+}
+
+// ReportRetryRejections comments the regenerated tests that were dropped on the
+// pull request opened from branch
+func ReportRetryRejections(owner, repo, branch string, rejected []string) error {
+	client, ctx, err := lib.GetClient()
+	if err != nil {
+		log.Printf("Error creating client: %v", err)
+		return err
+	}
+
+	prNumber, err := lib.FindPullRequestNumber(client, ctx, owner, repo, branch)
+	if err != nil {
+		return err
+	}
+
+	summary := &PullRequestSummary{Rejected: rejected}
+	return lib.CreateComment(client, ctx, owner, repo, prNumber, "CODESOURCERER regenerated the failing tests.\n"+summary.Problems())
+}
+
+// retryHint tells how to retry, since tests are only generated when a pull request is merged
+const retryHint = "Tests are only generated when a pull request is merged, so pushing a new commit will not retry. Redeliver the pull_request webhook from the Advanced settings of the GitHub App, or open and merge a new pull request.\n"
+
+// FormatServiceError renders a failed generation as a pull request comment,
+// telling the user whether waiting or changing the request will help
+func FormatServiceError(err error) string {
+	var serviceErr *connections.ServiceError
+	if !errors.As(err, &serviceErr) {
+		return "CODESOURCERER could not generate tests for this pull request.\n"
+	}
+
+	switch {
+	case errors.Is(err, connections.ErrResourceExhausted):
+		return "CODESOURCERER could not generate tests because the model quota is exhausted. Wait for the quota to reset, then retry.\n" + retryHint
+	case errors.Is(err, connections.ErrUnavailable), errors.Is(err, connections.ErrDeadlineExceeded):
+		return fmt.Sprintf("CODESOURCERER could not generate tests because the %s service is unavailable. Wait a few minutes, then retry.\n", serviceErr.Service) + retryHint
+	case errors.Is(err, connections.ErrInvalidArgument):
+		return fmt.Sprintf("CODESOURCERER could not generate tests for this pull request: %s\n", serviceErr.Message)
+	}
+	return "CODESOURCERER could not generate tests for this pull request.\n"
+}
+
+// FormatConfigError renders the configuration problems as a pull request comment
+func FormatConfigError(configErr *lib.ConfigError) string {
+	var body strings.Builder
+
+	body.WriteString("CODESOURCERER could not use the configuration, so no tests were generated:\n\n")
+	for _, problem := range configErr.Problems {
+		fmt.Fprintf(&body, "- %s\n", problem)
+	}
+
+	return body.String()
+}
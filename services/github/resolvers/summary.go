@@ -0,0 +1,206 @@
+package resolvers
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	pb "github.com/codesourcerer-bot/proto/generated"
+	"gopkg.in/yaml.v3"
+)
+
+// PullRequestSummary collects what is reported in the body of the generated pull request
+type PullRequestSummary struct {
+	CacheResult string
+	Groups      []*ConfigGroup
+	Rejected    []string
+	Skipped     []*pb.SkippedFile
+	Model       string
+	// PromptVersion lists the prompt templates the tests were generated with
+	PromptVersion string
+	// LocalRuns are the pre-runs in the runner service, empty when it is disabled
+	LocalRuns []LocalRun
+	// Tests are the committed tests, whose cases are listed for review
+	Tests []*pb.TestFilePayload
+	// Usage is the token usage of the model calls made for the merge
+	Usage []*pb.TokenUsage
+}
+
+// maxChecklistCases keeps the checklist well below the size limit of a pull request body
+const maxChecklistCases = 200
+
+var categoryLabels = map[string]string{
+	"happy_path": "happy path",
+	"edge_case":  "edge case",
+	"error":      "error",
+}
+
+// Body renders the summary as the pull request description
+func (s *PullRequestSummary) Body() string {
+	var body strings.Builder
+
+	body.WriteString("This is a draft PR created from the sandbox branch.\n")
+
+	switch s.CacheResult {
+
+	case "DONE":
+		body.WriteString("This PR has been cached!\n")
+
+	case "ERROR":
+		body.WriteString("This PR could not be cached!\n")
+	}
+
+	if s.Model != "" {
+		fmt.Fprintf(&body, "Tests were generated by %s.\n", s.Model)
+	}
+
+	if s.PromptVersion != "" {
+		fmt.Fprintf(&body, "Prompt templates: %s.\n", s.PromptVersion)
+	}
+
+	if len(s.LocalRuns) > 0 {
+		body.WriteString("\nThe tests were run before pushing:\n")
+		for _, run := range s.LocalRuns {
+			fmt.Fprintf(&body, "- %s\n", run.describe())
+		}
+	}
+
+	body.WriteString(s.Problems())
+	body.WriteString(s.Checklist())
+	body.WriteString(s.TokenUsage())
+
+	for _, group := range s.Groups {
+		if config, err := yaml.Marshal(group.Config); err != nil {
+			log.Printf("unable to render effective configuration: %v", err)
+		} else {
+			fmt.Fprintf(&body, "\n<details>\n<summary>Effective configuration (%s)</summary>\n\n```yaml\n%s```\n</details>\n", group.Scope(), config)
+		}
+	}
+
+	return body.String()
+}
+
+// Checklist renders the cases of every test file for reviewers to tick off.
+// Tests generated without a case breakdown are left out.
+func (s *PullRequestSummary) Checklist() string {
+	var body strings.Builder
+	listed, total := 0, 0
+
+	for _, test := range s.Tests {
+		total += len(test.GetCases())
+		if len(test.GetCases()) == 0 || listed >= maxChecklistCases {
+			continue
+		}
+
+		if listed == 0 {
+			body.WriteString("\n### Test cases\n")
+		}
+		fmt.Fprintf(&body, "\n%s covers %s:\n", markdownCode(test.GetTestfilepath()), markdownCode(test.GetParentpath()))
+
+		for _, c := range test.GetCases() {
+			if listed >= maxChecklistCases {
+				break
+			}
+			listed++
+
+			fmt.Fprintf(&body, "- [ ] %s", markdownCode(c.GetName()))
+			if c.GetTarget() != "" {
+				fmt.Fprintf(&body, " on %s", markdownCode(c.GetTarget()))
+			}
+			if label, ok := categoryLabels[c.GetCategory()]; ok {
+				fmt.Fprintf(&body, " (%s)", label)
+			}
+			if c.GetRationale() != "" {
+				fmt.Fprintf(&body, ": %s", markdownText(c.GetRationale()))
+			}
+			body.WriteString("\n")
+		}
+	}
+
+	if listed < total {
+		fmt.Fprintf(&body, "\n%d more test cases are not listed.\n", total-listed)
+	}
+	return body.String()
+}
+
+// markdownEscaper escapes the characters that would format text in a list item
+var markdownEscaper = strings.NewReplacer(
+	"\\", "\\\\", "`", "\\`", "*", "\\*", "_", "\\_", "[", "\\[", "]", "\\]",
+	"<", "&lt;", ">", "&gt;", "|", "\\|", "#", "\\#",
+)
+
+// singleLine folds the text written by the model onto one line, since a line
+// break would end the list item it is rendered in
+func singleLine(text string) string {
+	return strings.Join(strings.Fields(text), " ")
+}
+
+// markdownText renders text from the model as plain text
+func markdownText(text string) string {
+	return markdownEscaper.Replace(singleLine(text))
+}
+
+// markdownCode renders text from the model as inline code, with a fence longer
+// than any run of backticks inside it
+func markdownCode(text string) string {
+	text = singleLine(text)
+
+	longest, run := 0, 0
+	for _, r := range text {
+		if r == '`' {
+			run++
+			longest = max(longest, run)
+		} else {
+			run = 0
+		}
+	}
+	if longest == 0 {
+		return "`" + text + "`"
+	}
+
+	fence := strings.Repeat("`", longest+1)
+	return fence + " " + text + " " + fence
+}
+
+// Problems renders the rejected tests and the skipped files
+func (s *PullRequestSummary) Problems() string {
+	var body strings.Builder
+
+	if len(s.Rejected) > 0 {
+		body.WriteString("\nSome generated tests were not committed because they could not be placed safely:\n")
+		for _, reason := range s.Rejected {
+			fmt.Fprintf(&body, "- %s\n", reason)
+		}
+	}
+
+	if len(s.Skipped) > 0 {
+		body.WriteString("\nNo tests were generated for these files:\n")
+		for _, f := range s.Skipped {
+			fmt.Fprintf(&body, "- `%s`: %s\n", f.GetPath(), f.GetReason())
+		}
+	}
+
+	return body.String()
+}
+
+// TokenUsage renders the tokens spent on the merge, with a breakdown per purpose and model
+func (s *PullRequestSummary) TokenUsage() string {
+	if len(s.Usage) == 0 {
+		return ""
+	}
+
+	var usage strings.Builder
+
+	total := UsageTotals(s.Usage)
+	fmt.Fprintf(&usage, "\nToken usage: %d prompt + %d completion = %d tokens over %d calls.\n", total.PromptTokens, total.CompletionTokens, total.TotalTokens, total.Calls)
+
+	usage.WriteString("\n<details>\n<summary>Token usage per model</summary>\n\n")
+	usage.WriteString("| Purpose | Model | Prompt | Completion | Total | Calls |\n")
+	usage.WriteString("| --- | --- | ---: | ---: | ---: | ---: |\n")
+	for _, u := range s.Usage {
+		fmt.Fprintf(&usage, "| %s | %s | %d | %d | %d | %d |\n", u.GetPurpose(), u.GetModel(), u.GetPromptTokens(), u.GetCompletionTokens(), u.GetTotalTokens(), u.GetCalls())
+	}
+	usage.WriteString("</details>\n")
+
+	return usage.String()
+}
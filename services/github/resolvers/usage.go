@@ -0,0 +1,33 @@
+package resolvers
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/codesourcerer-bot/github/connections"
+	pb "github.com/codesourcerer-bot/proto/generated"
+)
+
+// UsageTotals sums the usage of every purpose and model
+func UsageTotals(usage []*pb.TokenUsage) *pb.TokenUsage {
+	total := &pb.TokenUsage{}
+	for _, u := range usage {
+		total.PromptTokens += u.GetPromptTokens()
+		total.CompletionTokens += u.GetCompletionTokens()
+		total.TotalTokens += u.GetTotalTokens()
+		total.Calls += u.GetCalls()
+	}
+	return total
+}
+
+// RecordUsage adds the usage to the totals of the merge in the database. Usage
+// is only reported, so a failure is logged and does not stop the run.
+func RecordUsage(mergeID, owner, repo string, usage []*pb.TokenUsage) {
+	if mergeID == "" || len(usage) == 0 {
+		return
+	}
+
+	if ok, err := connections.RecordUsageToDatabase(mergeID, fmt.Sprintf("%s/%s", owner, repo), usage); err != nil || !ok {
+		log.Printf("unable to record token usage of %s: %v", mergeID, err)
+	}
+}
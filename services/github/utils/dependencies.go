@@ -1,26 +1,119 @@
 package utils
 
 import (
-	"log"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
 	"strings"
+
+	"gopkg.in/yaml.v3"
 )
 
-// Function to filter dependencies for a specific file
-func FilterDependenciesForFile(filePath string, dependencies map[string][]string) []string {
-	// Check if specific dependencies are mentioned for the file
-	if deps, exists := dependencies[filePath]; exists && len(deps) > 0 {
-		return deps
+// PRDirectives holds the generation instructions written in a pull request description
+type PRDirectives struct {
+	Context   string                   `yaml:"context"`
+	Files     map[string]FileDirective `yaml:"files"`
+	Include   []string                 `yaml:"include"`
+	Exclude   []string                 `yaml:"exclude"`
+	Framework string                   `yaml:"framework"`
+	Skip      bool                     `yaml:"skip"`
+}
+
+// FileDirective holds the instructions for the files matching a path or glob
+type FileDirective struct {
+	Context      string   `yaml:"context"`
+	Dependencies []string `yaml:"dependencies"`
+}
+
+var directiveBlockRegex = regexp.MustCompile("(?s)```codesourcerer[ \t]*\r?\n(.*?)\r?\n?```")
+
+// ForFile merges every file directive whose key matches the given path
+func (d *PRDirectives) ForFile(filePath string) FileDirective {
+	var contexts []string
+	var merged FileDirective
+
+	patterns := make([]string, 0, len(d.Files))
+	for pattern := range d.Files {
+		patterns = append(patterns, pattern)
+	}
+	sort.Strings(patterns)
+
+	for _, pattern := range patterns {
+		if pattern != filePath && !MatchGlob(pattern, filePath) {
+			continue
+		}
+		directive := d.Files[pattern]
+		if directive.Context != "" {
+			contexts = append(contexts, strings.TrimSpace(directive.Context))
+		}
+		merged.Dependencies = append(merged.Dependencies, directive.Dependencies...)
+	}
+
+	merged.Context = strings.Join(contexts, "\n")
+	return merged
+}
+
+// ShouldProcess applies the include and exclude lists to a changed file
+func (d *PRDirectives) ShouldProcess(filePath string) bool {
+	if len(d.Include) > 0 && !MatchesAny(d.Include, filePath) {
+		return false
+	}
+	return !MatchesAny(d.Exclude, filePath)
+}
+
+// ParsePRDescription reads the fenced codesourcerer YAML block along with the
+// legacy $file: / $dependencies: / $context: lines
+func ParsePRDescription(description string) (*PRDirectives, error) {
+	directives := parseLegacyDirectives(directiveBlockRegex.ReplaceAllString(description, ""))
+
+	for _, match := range directiveBlockRegex.FindAllStringSubmatch(description, -1) {
+		var block PRDirectives
+
+		decoder := yaml.NewDecoder(bytes.NewBufferString(match[1]))
+		decoder.KnownFields(true)
+		if err := decoder.Decode(&block); err != nil && !errors.Is(err, io.EOF) {
+			return directives, fmt.Errorf("unable to parse codesourcerer block: %v", err)
+		}
+
+		directives.merge(&block)
+	}
+
+	return directives, nil
+}
+
+func (d *PRDirectives) merge(block *PRDirectives) {
+	if context := strings.TrimSpace(block.Context); context != "" {
+		if d.Context != "" {
+			d.Context += "\n"
+		}
+		d.Context += context
+	}
+
+	for pattern, directive := range block.Files {
+		existing := d.Files[pattern]
+		if directive.Context != "" {
+			existing.Context = directive.Context
+		}
+		existing.Dependencies = append(existing.Dependencies, directive.Dependencies...)
+		d.Files[pattern] = existing
 	}
 
-	// Default to no dependencies if not specified
-	log.Printf("No specific dependencies found for file: %s. Using the file itself.", filePath)
-	return []string{}
+	d.Include = append(d.Include, block.Include...)
+	d.Exclude = append(d.Exclude, block.Exclude...)
+
+	if block.Framework != "" {
+		d.Framework = block.Framework
+	}
+	d.Skip = d.Skip || block.Skip
 }
 
-func ParsePRDescription(description string) (map[string][]string, string) {
+func parseLegacyDirectives(description string) *PRDirectives {
 	lines := strings.Split(description, "\n")
-	dependencies := make(map[string][]string)
-	var context string
+	directives := &PRDirectives{Files: make(map[string]FileDirective)}
+	var contexts []string
 	var currentFile string
 
 	for _, line := range lines {
@@ -34,13 +127,20 @@ func ParsePRDescription(description string) (map[string][]string, string) {
 			// Extract dependencies for the current file
 			if currentFile != "" {
 				dependencyList := strings.TrimSpace(strings.TrimPrefix(line, "$dependencies:"))
-				dependencies[currentFile] = append(dependencies[currentFile], strings.Split(dependencyList, ",")...)
+				directive := directives.Files[currentFile]
+				for _, dep := range strings.Split(dependencyList, ",") {
+					if dep = strings.TrimSpace(dep); dep != "" {
+						directive.Dependencies = append(directive.Dependencies, dep)
+					}
+				}
+				directives.Files[currentFile] = directive
 			}
 		case strings.HasPrefix(line, "$context:"):
 			// Extract the context
-			context = strings.TrimSpace(strings.TrimPrefix(line, "$context:"))
+			contexts = append(contexts, strings.TrimSpace(strings.TrimPrefix(line, "$context:")))
 		}
 	}
 
-	return dependencies, context
+	directives.Context = strings.Join(contexts, "\n")
+	return directives
 }
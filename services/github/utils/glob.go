@@ -0,0 +1,82 @@
+package utils
+
+import (
+	"path"
+	"strings"
+)
+
+// MatchGlob reports whether a slash separated path matches the pattern.
+// Besides the path.Match syntax, "**" matches any number of directories.
+func MatchGlob(pattern, filePath string) bool {
+	pattern = strings.Trim(pattern, "/")
+	filePath = strings.Trim(filePath, "/")
+
+	return matchSegments(strings.Split(pattern, "/"), strings.Split(filePath, "/"))
+}
+
+// MatchesAny reports whether the path matches at least one of the patterns
+func MatchesAny(patterns []string, filePath string) bool {
+	for _, pattern := range patterns {
+		if MatchGlob(pattern, filePath) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsGlob reports whether the pattern contains any wildcard characters
+func IsGlob(pattern string) bool {
+	return strings.ContainsAny(pattern, "*?[")
+}
+
+// ExpandGlobs resolves every pattern against the given paths. Plain paths are kept as they are.
+func ExpandGlobs(patterns []string, paths []string) []string {
+	var expanded []string
+	for _, pattern := range patterns {
+		pattern = strings.TrimSpace(pattern)
+		if pattern == "" {
+			continue
+		}
+
+		if !IsGlob(pattern) {
+			expanded = append(expanded, pattern)
+			continue
+		}
+
+		for _, p := range paths {
+			if MatchGlob(pattern, p) {
+				expanded = append(expanded, p)
+			}
+		}
+	}
+	return expanded
+}
+
+func matchSegments(pattern, segments []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			// collapse consecutive "**" and try every possible split
+			for len(pattern) > 1 && pattern[1] == "**" {
+				pattern = pattern[1:]
+			}
+			for i := 0; i <= len(segments); i++ {
+				if matchSegments(pattern[1:], segments[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+
+		if len(segments) == 0 {
+			return false
+		}
+
+		if ok, err := path.Match(pattern[0], segments[0]); err != nil || !ok {
+			return false
+		}
+
+		pattern, segments = pattern[1:], segments[1:]
+	}
+
+	return len(segments) == 0
+}
@@ -0,0 +1,31 @@
+package utils
+
+import "testing"
+
+func TestMatchGlob(t *testing.T) {
+	tests := []struct {
+		pattern string
+		path    string
+		want    bool
+	}{
+		{pattern: "src/*.py", path: "src/app.py", want: true},
+		{pattern: "src/*.py", path: "src/pkg/app.py", want: false},
+		{pattern: "src/**/*.py", path: "src/app.py", want: true},
+		{pattern: "src/**/*.py", path: "src/a/b/c/app.py", want: true},
+		{pattern: "src/**/*.py", path: "lib/app.py", want: false},
+		{pattern: "**", path: "any/depth/file.go", want: true},
+		{pattern: "**/**/test_*.py", path: "tests/test_app.py", want: true},
+		{pattern: "docs/**", path: "docs", want: true},
+		{pattern: "/src/*.py", path: "src/app.py/", want: true},
+		{pattern: "src/?.py", path: "src/ab.py", want: false},
+		{pattern: "src/[ab].py", path: "src/b.py", want: true},
+		{pattern: "src/[.py", path: "src/[.py", want: false},
+		{pattern: "app.py", path: "app.py", want: true},
+	}
+
+	for _, tt := range tests {
+		if got := MatchGlob(tt.pattern, tt.path); got != tt.want {
+			t.Errorf("MatchGlob(%q, %q) = %v, want %v", tt.pattern, tt.path, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,114 @@
+package utils
+
+import (
+	"strings"
+)
+
+// PathRule is a gitignore style rule. The last matching rule decides whether a path is excluded.
+type PathRule struct {
+	globs   []string
+	exclude bool
+}
+
+// PathFilter decides which changed files are sent for test generation
+type PathFilter struct {
+	Include []string
+	Exclude []string
+	Rules   []PathRule
+}
+
+// WithGlobs returns a copy of the filter using the given include and exclude globs
+func (f *PathFilter) WithGlobs(include, exclude []string) *PathFilter {
+	return &PathFilter{Include: include, Exclude: exclude, Rules: f.Rules}
+}
+
+// ShouldProcess reports whether the file passes the include list, the exclude list and the ignore rules
+func (f *PathFilter) ShouldProcess(filePath string) bool {
+	if len(f.Include) > 0 && !MatchesAny(f.Include, filePath) {
+		return false
+	}
+
+	if MatchesAny(f.Exclude, filePath) {
+		return false
+	}
+
+	excluded := false
+	for _, rule := range f.Rules {
+		if MatchesAny(rule.globs, filePath) {
+			excluded = rule.exclude
+		}
+	}
+
+	return !excluded
+}
+
+// ParseIgnoreFile reads a .codesourcererignore file, which follows the .gitignore syntax
+func ParseIgnoreFile(content string) []PathRule {
+	var rules []PathRule
+
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		exclude := true
+		if strings.HasPrefix(line, "!") {
+			exclude = false
+			line = line[1:]
+		}
+
+		if globs := ignorePatternToGlobs(line); len(globs) > 0 {
+			rules = append(rules, PathRule{globs: globs, exclude: exclude})
+		}
+	}
+
+	return rules
+}
+
+// ParseGitAttributes turns linguist-generated and linguist-vendored attributes into exclusion rules
+func ParseGitAttributes(content string) []PathRule {
+	var rules []PathRule
+
+	for _, line := range strings.Split(content, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 2 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+
+		for _, attr := range fields[1:] {
+			name, value, _ := strings.Cut(strings.TrimPrefix(attr, "-"), "=")
+			if name != "linguist-generated" && name != "linguist-vendored" {
+				continue
+			}
+
+			exclude := !strings.HasPrefix(attr, "-") && value != "false"
+			if globs := ignorePatternToGlobs(fields[0]); len(globs) > 0 {
+				rules = append(rules, PathRule{globs: globs, exclude: exclude})
+			}
+		}
+	}
+
+	return rules
+}
+
+// ignorePatternToGlobs expands a gitignore pattern into globs understood by MatchGlob
+func ignorePatternToGlobs(pattern string) []string {
+	dirOnly := strings.HasSuffix(pattern, "/")
+	rooted := strings.HasPrefix(pattern, "/")
+	pattern = strings.Trim(pattern, "/")
+	if pattern == "" {
+		return nil
+	}
+
+	// Patterns without a leading or middle slash match at any depth
+	anchored := rooted || strings.Contains(pattern, "/")
+	if !anchored {
+		pattern = "**/" + pattern
+	}
+
+	if dirOnly {
+		return []string{pattern + "/**"}
+	}
+	return []string{pattern, pattern + "/**"}
+}
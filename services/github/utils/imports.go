@@ -0,0 +1,196 @@
+package utils
+
+import (
+	"go/parser"
+	"go/token"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var (
+	pythonImportRegex     = regexp.MustCompile(`^\s*import\s+([\w.]+(?:\s+as\s+\w+)?(?:\s*,\s*[\w.]+(?:\s+as\s+\w+)?)*)`)
+	pythonFromImportRegex = regexp.MustCompile(`^\s*from\s+(\.*)([\w.]*)\s+import\s+\(?\s*([\w\s,*]+)`)
+	jsImportRegex         = regexp.MustCompile(`(?:import|export)\s+(?:[^'"]*?\s+from\s+)?['"]([^'"]+)['"]|require\(\s*['"]([^'"]+)['"]\s*\)|import\(\s*['"]([^'"]+)['"]\s*\)`)
+	goModuleRegex         = regexp.MustCompile(`(?m)^\s*module\s+"?([^\s"]+)"?`)
+)
+
+var jsExtensions = []string{".ts", ".tsx", ".js", ".jsx", ".mjs", ".cjs"}
+
+// ParseGoModulePath extracts the module path declared in a go.mod file
+func ParseGoModulePath(content string) string {
+	if match := goModuleRegex.FindStringSubmatch(content); match != nil {
+		return match[1]
+	}
+	return ""
+}
+
+// ResolveImports discovers the repository files imported by the given file.
+// tree holds every path in the repository and goModules maps a Go module path to its directory.
+func ResolveImports(filePath, content string, tree map[string]bool, goModules map[string]string) []string {
+	var resolved []string
+
+	switch ext := path.Ext(filePath); {
+	case ext == ".py":
+		resolved = resolvePythonImports(filePath, content, tree)
+	case ext == ".go":
+		resolved = resolveGoImports(filePath, content, tree, goModules)
+	case isJSExtension(ext):
+		resolved = resolveJSImports(filePath, content, tree)
+	}
+
+	return uniqueSorted(resolved, filePath)
+}
+
+func resolvePythonImports(filePath, content string, tree map[string]bool) []string {
+	var resolved []string
+	dir := path.Dir(filePath)
+
+	for _, line := range strings.Split(content, "\n") {
+		if match := pythonFromImportRegex.FindStringSubmatch(line); match != nil {
+			dots, module := match[1], match[2]
+
+			base := ""
+			if dots != "" {
+				base = dir
+				for i := 1; i < len(dots); i++ {
+					base = path.Dir(base)
+				}
+			}
+
+			modulePath := path.Join(base, strings.ReplaceAll(module, ".", "/"))
+			resolved = append(resolved, pythonModuleFiles(modulePath, tree)...)
+
+			// "from pkg import mod" may refer to a submodule rather than a symbol
+			for _, name := range strings.Split(match[3], ",") {
+				fields := strings.Fields(name)
+				if len(fields) > 0 && fields[0] != "*" {
+					resolved = append(resolved, pythonModuleFiles(path.Join(modulePath, fields[0]), tree)...)
+				}
+			}
+			continue
+		}
+
+		if match := pythonImportRegex.FindStringSubmatch(line); match != nil {
+			for _, module := range strings.Split(match[1], ",") {
+				if fields := strings.Fields(module); len(fields) > 0 {
+					resolved = append(resolved, pythonModuleFiles(strings.ReplaceAll(fields[0], ".", "/"), tree)...)
+				}
+			}
+		}
+	}
+
+	return resolved
+}
+
+func pythonModuleFiles(modulePath string, tree map[string]bool) []string {
+	modulePath = strings.TrimPrefix(path.Clean(modulePath), "./")
+	if modulePath == "." || modulePath == "" {
+		return nil
+	}
+
+	for _, candidate := range []string{modulePath + ".py", modulePath + "/__init__.py"} {
+		if tree[candidate] {
+			return []string{candidate}
+		}
+	}
+	return nil
+}
+
+func resolveJSImports(filePath, content string, tree map[string]bool) []string {
+	var resolved []string
+	dir := path.Dir(filePath)
+
+	for _, match := range jsImportRegex.FindAllStringSubmatch(content, -1) {
+		spec := match[1] + match[2] + match[3]
+		if !strings.HasPrefix(spec, "./") && !strings.HasPrefix(spec, "../") {
+			continue
+		}
+
+		target := path.Join(dir, spec)
+		candidates := []string{target}
+		for _, ext := range jsExtensions {
+			candidates = append(candidates, target+ext)
+		}
+		for _, ext := range jsExtensions {
+			candidates = append(candidates, target+"/index"+ext)
+		}
+
+		for _, candidate := range candidates {
+			if tree[candidate] {
+				resolved = append(resolved, candidate)
+				break
+			}
+		}
+	}
+
+	return resolved
+}
+
+func resolveGoImports(filePath, content string, tree map[string]bool, goModules map[string]string) []string {
+	file, err := parser.ParseFile(token.NewFileSet(), filePath, content, parser.ImportsOnly)
+	if err != nil {
+		return nil
+	}
+
+	var resolved []string
+	for _, spec := range file.Imports {
+		importPath, err := strconv.Unquote(spec.Path.Value)
+		if err != nil {
+			continue
+		}
+
+		dir, ok := goPackageDir(importPath, goModules)
+		if !ok {
+			continue
+		}
+
+		for candidate := range tree {
+			if path.Dir(candidate) == dir && strings.HasSuffix(candidate, ".go") && !strings.HasSuffix(candidate, "_test.go") {
+				resolved = append(resolved, candidate)
+			}
+		}
+	}
+
+	return resolved
+}
+
+// goPackageDir maps an import path onto a repository directory using the longest matching module
+func goPackageDir(importPath string, goModules map[string]string) (string, bool) {
+	bestModule := ""
+	for module := range goModules {
+		if (importPath == module || strings.HasPrefix(importPath, module+"/")) && len(module) > len(bestModule) {
+			bestModule = module
+		}
+	}
+	if bestModule == "" {
+		return "", false
+	}
+
+	rest := strings.TrimPrefix(importPath, bestModule)
+	return path.Join(goModules[bestModule], rest), true
+}
+
+func isJSExtension(ext string) bool {
+	for _, e := range jsExtensions {
+		if e == ext {
+			return true
+		}
+	}
+	return false
+}
+
+func uniqueSorted(paths []string, exclude string) []string {
+	seen := map[string]bool{exclude: true}
+	var unique []string
+	for _, p := range paths {
+		if !seen[p] {
+			seen[p] = true
+			unique = append(unique, p)
+		}
+	}
+	sort.Strings(unique)
+	return unique
+}
@@ -0,0 +1,119 @@
+package utils
+
+import (
+	"fmt"
+	"path"
+	"strings"
+)
+
+// protectedFiles can never be written on the sandbox branch, wherever they live
+var protectedFiles = []string{"codesourcerer-config.yml", ".codesourcererignore", ".gitattributes"}
+
+// protectedDir holds the workflows, which would run with the repository secrets
+const protectedDir = ".github/workflows"
+
+// NormalizeTestPath cleans a path returned by the model. Absolute paths, drive
+// letters and paths climbing out of the repository with ".." are rejected.
+func NormalizeTestPath(testPath string) (string, error) {
+	slashed := strings.ReplaceAll(testPath, "\\", "/")
+
+	if strings.TrimSpace(slashed) == "" {
+		return "", fmt.Errorf("empty test file path")
+	}
+
+	if strings.HasPrefix(slashed, "/") || (len(slashed) > 1 && slashed[1] == ':') {
+		return "", fmt.Errorf("test file path %q is absolute", testPath)
+	}
+
+	for _, segment := range strings.Split(slashed, "/") {
+		if segment == ".." {
+			return "", fmt.Errorf("test file path %q leaves its directory", testPath)
+		}
+	}
+
+	return path.Clean(slashed), nil
+}
+
+// IsProtectedPath reports whether the bot must never write to the path
+func IsProtectedPath(filePath string) bool {
+	lower := strings.ToLower(path.Clean(strings.TrimPrefix(filePath, "/")))
+
+	if lower == protectedDir || strings.HasPrefix(lower, protectedDir+"/") {
+		return true
+	}
+
+	base := path.Base(lower)
+	for _, name := range protectedFiles {
+		if base == name {
+			return true
+		}
+	}
+	return false
+}
+
+// IsTestFileName reports whether the file name follows the test-file pattern of
+// the framework. Without a framework any supported pattern is accepted.
+func IsTestFileName(framework, filePath string) bool {
+	base := path.Base(filePath)
+	ext := path.Ext(base)
+	stem := strings.TrimSuffix(base, ext)
+
+	switch framework {
+	case "go-test":
+		return ext == ".go" && strings.HasSuffix(stem, "_test")
+	case "pytest", "unittest":
+		return ext == ".py" && (strings.HasPrefix(stem, "test_") || strings.HasSuffix(stem, "_test"))
+	case "jest", "vitest", "mocha":
+		return isJSExtension(ext) && (strings.HasSuffix(stem, ".test") || strings.HasSuffix(stem, ".spec"))
+	case "junit":
+		return (ext == ".java" || ext == ".kt") && (strings.HasSuffix(stem, "Test") || strings.HasSuffix(stem, "Tests"))
+	case "":
+		for _, f := range []string{"go-test", "pytest", "jest", "junit"} {
+			if IsTestFileName(f, filePath) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// CheckWritablePath is the last check before a file is committed to the sandbox branch
+func CheckWritablePath(filePath string) error {
+	normalized, err := NormalizeTestPath(filePath)
+	if err != nil {
+		return err
+	}
+
+	if IsProtectedPath(normalized) {
+		return fmt.Errorf("test file path %q is protected", filePath)
+	}
+
+	if !IsTestFileName("", normalized) {
+		return fmt.Errorf("test file path %q is not named like a test file", filePath)
+	}
+
+	return nil
+}
+
+// ValidateTestPath checks a placed test path against the framework: it must be
+// writable, named like a test file and inside the directories allowed for the
+// file it covers.
+func ValidateTestPath(framework, testDirectory, placement, parentPath, testPath string) error {
+	if err := CheckWritablePath(testPath); err != nil {
+		return err
+	}
+
+	if !IsTestFileName(framework, testPath) {
+		return fmt.Errorf("test file path %q does not match the %s test file pattern", testPath, framework)
+	}
+
+	placed, err := PlaceTestFile(framework, testDirectory, placement, parentPath, testPath)
+	if err != nil {
+		return err
+	}
+	if placed != path.Clean(testPath) {
+		return fmt.Errorf("test file path %q is outside the directories allowed for %s", testPath, parentPath)
+	}
+
+	return nil
+}
@@ -0,0 +1,58 @@
+package utils
+
+import "testing"
+
+func TestNormalizeTestPath(t *testing.T) {
+	tests := []struct {
+		path    string
+		want    string
+		wantErr bool
+	}{
+		{path: "tests/test_app.py", want: "tests/test_app.py"},
+		{path: "tests//unit/./test_app.py", want: "tests/unit/test_app.py"},
+		{path: `tests\unit\test_app.py`, want: "tests/unit/test_app.py"},
+		{path: "", wantErr: true},
+		{path: "   ", wantErr: true},
+		{path: "/tests/test_app.py", wantErr: true},
+		{path: `\tests\test_app.py`, wantErr: true},
+		{path: "C:/tests/test_app.py", wantErr: true},
+		{path: "../test_app.py", wantErr: true},
+		{path: "tests/../../test_app.py", wantErr: true},
+		{path: "tests/../test_app.py", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := NormalizeTestPath(tt.path)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("NormalizeTestPath(%q) error = %v, want error %v", tt.path, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("NormalizeTestPath(%q) = %q, want %q", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestCheckWritablePath(t *testing.T) {
+	tests := []struct {
+		path    string
+		wantErr bool
+	}{
+		{path: "tests/test_app.py"},
+		{path: "pkg/app_test.go"},
+		{path: "src/app.test.ts"},
+		{path: "src/test/java/AppTest.java"},
+		{path: "tests/helpers.py", wantErr: true},
+		{path: "/tests/test_app.py", wantErr: true},
+		{path: "../tests/test_app.py", wantErr: true},
+		{path: ".github/workflows/test_ci.py", wantErr: true},
+		{path: ".GitHub/Workflows/deploy_test.go", wantErr: true},
+		{path: "tests/codesourcerer-config.yml", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		if err := CheckWritablePath(tt.path); (err != nil) != tt.wantErr {
+			t.Errorf("CheckWritablePath(%q) error = %v, want error %v", tt.path, err, tt.wantErr)
+		}
+	}
+}
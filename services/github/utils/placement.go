@@ -0,0 +1,85 @@
+package utils
+
+import (
+	"fmt"
+	"path"
+	"strings"
+)
+
+// PlaceTestFile returns where the test for parentPath must live under the
+// conventions of the framework. A proposed path that already follows them is
+// kept, otherwise it is rewritten. Tests that cannot be placed are rejected.
+func PlaceTestFile(framework, testDirectory, placement, parentPath, proposed string) (string, error) {
+	parentPath = strings.TrimPrefix(path.Clean("/"+parentPath), "/")
+	proposed = strings.TrimPrefix(path.Clean("/"+proposed), "/")
+
+	if parentPath == "" {
+		return "", fmt.Errorf("test %q does not name the file it covers", proposed)
+	}
+
+	dir := path.Dir(parentPath)
+	ext := path.Ext(parentPath)
+	stem := strings.TrimSuffix(path.Base(parentPath), ext)
+
+	switch framework {
+	case "go-test":
+		if ext != ".go" {
+			return "", fmt.Errorf("go-test cannot cover %s", parentPath)
+		}
+		if path.Dir(proposed) == dir && strings.HasSuffix(proposed, "_test.go") {
+			return proposed, nil
+		}
+		return path.Join(dir, stem+"_test.go"), nil
+
+	case "pytest", "unittest":
+		testDir := strings.Trim(testDirectory, "/")
+		if testDir == "" {
+			testDir = "tests"
+		}
+		// The source directory is mirrored, and named in the file as well: without
+		// __init__.py files pytest imports tests by their base name, so modules
+		// sharing a name in different packages must not share a test file name
+		name := stem
+		if dir != "." {
+			name = strings.ReplaceAll(dir, "/", "_") + "_" + stem
+		}
+		return path.Join(testDir, dir, "test_"+name+".py"), nil
+
+	case "jest", "vitest", "mocha":
+		if !isJSExtension(ext) {
+			ext = ".js"
+		}
+		name := stem + ".test" + ext
+		colocated := path.Join(dir, name)
+		nested := path.Join(dir, "__tests__", name)
+
+		base := path.Base(proposed)
+		isTestName := strings.HasSuffix(base, ".test"+ext) || strings.HasSuffix(base, ".spec"+ext)
+		switch {
+		case isTestName && path.Dir(proposed) == dir && placement != "__tests__":
+			return proposed, nil
+		case isTestName && path.Dir(proposed) == path.Join(dir, "__tests__") && placement != "colocated":
+			return proposed, nil
+		case placement == "__tests__":
+			return nested, nil
+		}
+		return colocated, nil
+
+	case "junit":
+		testDir := dir
+		if strings.Contains(dir+"/", "src/main/") {
+			testDir = strings.Replace(dir+"/", "src/main/", "src/test/", 1)
+			testDir = strings.TrimSuffix(testDir, "/")
+		}
+		base := path.Base(proposed)
+		if path.Dir(proposed) == testDir && (strings.HasSuffix(base, "Test"+ext) || strings.HasSuffix(base, "Tests"+ext)) {
+			return proposed, nil
+		}
+		return path.Join(testDir, stem+"Test"+ext), nil
+	}
+
+	if proposed == "" {
+		return "", fmt.Errorf("no test file path for %s", parentPath)
+	}
+	return proposed, nil
+}
@@ -0,0 +1,567 @@
+package utils
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/scanner"
+	"go/token"
+	"path"
+	"regexp"
+	"strings"
+)
+
+var (
+	pythonDeclRegex = regexp.MustCompile(`^(?:async\s+def|def|class)\s+(\w+)|^(\w+)\s*(?::[^=]*)?=[^=]`)
+	jsDeclRegex     = regexp.MustCompile(`^(?:export\s+)?(?:default\s+)?(?:declare\s+)?(?:async\s+)?(?:abstract\s+)?(?:function\*?|class|const|let|var|interface|type|enum)\s+([\w$]+)`)
+	jsImportLine    = regexp.MustCompile(`^import\b|^(?:const|let|var)\s+[^=]*=\s*require\(`)
+	identRegex      = regexp.MustCompile(`[A-Za-z_$][\w$]*`)
+
+	// pythonContinuation matches the clauses that continue the previous top-level statement
+	pythonContinuation = regexp.MustCompile(`^(?:else|elif|except|finally)\b`)
+
+	pythonDefLine   = regexp.MustCompile(`^(?:async\s+)?def\s`)
+	pythonDocstring = regexp.MustCompile(`^[rRuUbBfF]{0,2}["']`)
+
+	// jsBodyPrefix matches what may sit between the parameters of a function and
+	// its body: an arrow or a TypeScript return type
+	jsBodyPrefix = regexp.MustCompile(`^\s*(?::[^{};=()]*)?(?:=>\s*)?$`)
+	// jsBlockStatement matches the statements whose braces are not a function body
+	jsBlockStatement = regexp.MustCompile(`\bclass\b|^\s*(?:if|else|for|while|do|switch|try|catch|finally|with)\b`)
+)
+
+// ReferencedIdentifiers collects every identifier used in the code of the file,
+// leaving out strings and comments. Unsupported languages return nil.
+func ReferencedIdentifiers(filePath, content string) map[string]bool {
+	switch ext := path.Ext(filePath); {
+	case ext == ".go":
+		return goIdentifiers(content)
+	case ext == ".py", isJSExtension(ext):
+		used := make(map[string]bool)
+		for _, code := range splitCode(filePath, content) {
+			for _, ident := range identRegex.FindAllString(code, -1) {
+				used[ident] = true
+			}
+		}
+		return used
+	}
+	return nil
+}
+
+func goIdentifiers(content string) map[string]bool {
+	used := make(map[string]bool)
+
+	fset := token.NewFileSet()
+	file := fset.AddFile("", fset.Base(), len(content))
+
+	var s scanner.Scanner
+	s.Init(file, []byte(content), nil, 0)
+	for {
+		_, tok, lit := s.Scan()
+		if tok == token.EOF {
+			return used
+		}
+		if tok == token.IDENT {
+			used[lit] = true
+		}
+	}
+}
+
+// TrimToSymbols keeps the imports of a dependency and the top-level declarations
+// named in used, along with their doc comments. Functions are cut down to their
+// signatures and docstrings. It reports false when the language is unsupported,
+// the file cannot be parsed or nothing would be saved, in which case the whole
+// file should be sent.
+func TrimToSymbols(filePath, content string, used map[string]bool) (string, bool) {
+	var chunks []symbolChunk
+	// Go chunks end at their declaration, line chunks keep the blank lines that follow them
+	comment, separator := "//", ""
+	// Go bodies are dropped while parsing
+	skeleton := func(text string) string { return text }
+
+	switch ext := path.Ext(filePath); {
+	case ext == ".go":
+		var ok bool
+		if chunks, ok = goChunks(content); !ok {
+			return "", false
+		}
+		separator = "\n"
+	case ext == ".py":
+		chunks = lineChunks(filePath, content, pythonChunkName)
+		comment = "#"
+		skeleton = func(text string) string { return pythonSkeleton(filePath, text) }
+	case isJSExtension(ext):
+		chunks = lineChunks(filePath, content, jsChunkName)
+		skeleton = func(text string) string { return jsSkeleton(filePath, text) }
+	default:
+		return "", false
+	}
+
+	var kept []string
+	matched, omitted := 0, 0
+
+	for _, chunk := range chunks {
+		switch {
+		case chunk.header:
+			kept = append(kept, chunk.text)
+		case chunk.usedIn(used):
+			kept = append(kept, skeleton(chunk.text))
+			matched++
+		default:
+			omitted++
+		}
+	}
+
+	if matched == 0 {
+		return "", false
+	}
+
+	if omitted > 0 {
+		kept = append(kept, fmt.Sprintf("%s %d unused declarations omitted by CODESOURCERER\n", comment, omitted))
+	}
+
+	trimmed := strings.Join(kept, separator)
+	if len(trimmed) >= len(content) {
+		return "", false
+	}
+	return trimmed, true
+}
+
+// symbolChunk is a top-level piece of a source file. Headers such as the
+// package clause and imports are always kept.
+type symbolChunk struct {
+	names  []string
+	header bool
+	text   string
+}
+
+func (c symbolChunk) usedIn(used map[string]bool) bool {
+	for _, name := range c.names {
+		if used[name] {
+			return true
+		}
+	}
+	return false
+}
+
+func goChunks(content string) ([]symbolChunk, bool) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "", content, parser.ParseComments)
+	if err != nil {
+		return nil, false
+	}
+
+	offset := func(pos token.Pos) int { return fset.Position(pos).Offset }
+
+	chunks := []symbolChunk{{header: true, text: content[offset(file.Package):offset(file.Name.End())] + "\n"}}
+
+	for _, decl := range file.Decls {
+		start, end := decl.Pos(), decl.End()
+
+		switch d := decl.(type) {
+		case *ast.FuncDecl:
+			if d.Doc != nil {
+				start = d.Doc.Pos()
+			}
+			text := content[offset(start):offset(end)] + "\n"
+			if d.Body != nil {
+				text = content[offset(start):offset(d.Body.Lbrace)] + "{ ... }\n"
+			}
+			chunks = append(chunks, symbolChunk{names: []string{d.Name.Name}, text: text})
+
+		case *ast.GenDecl:
+			if d.Doc != nil {
+				start = d.Doc.Pos()
+			}
+			text := content[offset(start):offset(end)] + "\n"
+
+			if d.Tok == token.IMPORT {
+				chunks = append(chunks, symbolChunk{header: true, text: text})
+				continue
+			}
+
+			// A grouped declaration is kept whole when any of its names is used
+			chunks = append(chunks, symbolChunk{names: genDeclNames(d), text: text})
+		}
+	}
+
+	return chunks, true
+}
+
+func genDeclNames(d *ast.GenDecl) []string {
+	var names []string
+	for _, spec := range d.Specs {
+		switch s := spec.(type) {
+		case *ast.TypeSpec:
+			names = append(names, s.Name.Name)
+		case *ast.ValueSpec:
+			for _, name := range s.Names {
+				names = append(names, name.Name)
+			}
+		}
+	}
+	return names
+}
+
+// lineChunks splits a Python or JS file into top-level chunks. A chunk starts on
+// a line at column 0 outside any string, comment or bracket. Comments and
+// decorators at column 0 are attached to the chunk that follows them.
+func lineChunks(filePath, content string, name func(line string) (string, bool)) []symbolChunk {
+	lines := strings.SplitAfter(content, "\n")
+	starts := topLevelLines(filePath, content)
+
+	var chunks []symbolChunk
+	var prefix strings.Builder
+	current := -1
+
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+
+		if !starts[i] || trimmed == "" || isContinuation(filePath, trimmed) {
+			if current >= 0 && prefix.Len() == 0 {
+				chunks[current].text += line
+			} else {
+				prefix.WriteString(line)
+			}
+			continue
+		}
+
+		if strings.HasPrefix(trimmed, "#") || strings.HasPrefix(trimmed, "//") || strings.HasPrefix(trimmed, "/*") || strings.HasPrefix(trimmed, "@") {
+			prefix.WriteString(line)
+			current = -1
+			continue
+		}
+
+		chunk := symbolChunk{text: prefix.String() + line}
+		if chunkName, header := name(trimmed); header {
+			chunk.header = true
+		} else if chunkName != "" {
+			chunk.names = []string{chunkName}
+		}
+		chunks = append(chunks, chunk)
+		prefix.Reset()
+		current = len(chunks) - 1
+	}
+
+	return chunks
+}
+
+func isContinuation(filePath, trimmed string) bool {
+	return path.Ext(filePath) == ".py" && pythonContinuation.MatchString(trimmed)
+}
+
+func pythonChunkName(line string) (string, bool) {
+	if strings.HasPrefix(line, "import ") || strings.HasPrefix(line, "from ") {
+		return "", true
+	}
+	if match := pythonDeclRegex.FindStringSubmatch(line); match != nil {
+		return match[1] + match[2], false
+	}
+	return "", false
+}
+
+func jsChunkName(line string) (string, bool) {
+	if jsImportLine.MatchString(line) {
+		return "", true
+	}
+	if match := jsDeclRegex.FindStringSubmatch(line); match != nil {
+		return match[1], false
+	}
+	return "", false
+}
+
+// pythonSkeleton replaces the bodies of the functions in a chunk, methods
+// included, with "..." and keeps their signatures and docstrings
+func pythonSkeleton(filePath, text string) string {
+	lines := strings.SplitAfter(text, "\n")
+	open := openLines(filePath, text)
+
+	var out strings.Builder
+	var blank strings.Builder
+	// skip is the indentation of the function whose body is being left out
+	skip := -1
+
+	for i := 0; i < len(lines); {
+		line := lines[i]
+		trimmed := strings.TrimSpace(line)
+
+		if skip >= 0 {
+			if trimmed == "" && open[i] {
+				blank.WriteString(line)
+				i++
+				continue
+			}
+			if !open[i] || indentation(line) > skip {
+				blank.Reset()
+				i++
+				continue
+			}
+			out.WriteString(blank.String())
+			blank.Reset()
+			skip = -1
+		}
+
+		if !open[i] || !pythonDefLine.MatchString(trimmed) {
+			out.WriteString(line)
+			i++
+			continue
+		}
+
+		indent := indentation(line)
+		i = writeStatement(&out, lines, open, i)
+
+		body := nextStatement(lines, open, i)
+		if body == len(lines) || indentation(lines[body]) <= indent {
+			continue
+		}
+		i = body
+		if pythonDocstring.MatchString(strings.TrimSpace(lines[body])) {
+			i = writeStatement(&out, lines, open, body)
+			if rest := nextStatement(lines, open, i); rest == len(lines) || indentation(lines[rest]) <= indent {
+				continue
+			}
+		}
+
+		out.WriteString(lines[body][:indentation(lines[body])] + "...\n")
+		skip = indent
+	}
+
+	out.WriteString(blank.String())
+	return out.String()
+}
+
+// writeStatement writes the statement starting at line i and returns the line after it
+func writeStatement(out *strings.Builder, lines []string, open []bool, i int) int {
+	out.WriteString(lines[i])
+	for i++; i < len(lines) && !open[i]; i++ {
+		out.WriteString(lines[i])
+	}
+	return i
+}
+
+// nextStatement returns the first non-blank line from i that starts a statement
+func nextStatement(lines []string, open []bool, i int) int {
+	for ; i < len(lines); i++ {
+		if open[i] && strings.TrimSpace(lines[i]) != "" {
+			return i
+		}
+	}
+	return len(lines)
+}
+
+func indentation(line string) int {
+	return len(line) - len(strings.TrimLeft(line, " \t"))
+}
+
+// jsSkeleton replaces the bodies of the functions, methods and arrow functions
+// of a chunk with "{ ... }", keeping their signatures and the comments before them
+func jsSkeleton(filePath, text string) string {
+	var out strings.Builder
+	var state lexState
+	statement, params := 0, -1
+
+	for i := 0; i < len(text); {
+		inCode := state.quote == "" && !state.blockComment && !state.lineComment
+		n := state.step(filePath, text, i)
+		stillCode := state.quote == "" && !state.blockComment && !state.lineComment
+
+		if inCode && stillCode && n == 1 {
+			switch text[i] {
+			case ')':
+				params = i + 1
+			case ';', '}':
+				statement, params = i+1, -1
+			case '{':
+				if isJSBody(text, statement, params, i) {
+					if end, ok := matchingBrace(filePath, text, i); ok {
+						out.WriteString("{ ... }")
+						i = end + 1
+						statement, params = i, -1
+						continue
+					}
+				}
+				statement, params = i+1, -1
+			}
+		}
+
+		out.WriteString(text[i : i+n])
+		i += n
+	}
+
+	return out.String()
+}
+
+// isJSBody reports whether the brace at i opens the body of a function
+func isJSBody(text string, statement, params, i int) bool {
+	if jsBlockStatement.MatchString(text[statement:i]) {
+		return false
+	}
+	if strings.HasSuffix(strings.TrimSpace(text[statement:i]), "=>") {
+		return true
+	}
+	return params >= 0 && jsBodyPrefix.MatchString(text[params:i])
+}
+
+// matchingBrace returns the index of the brace closing the one at open
+func matchingBrace(filePath, text string, open int) (int, bool) {
+	var state lexState
+	depth := 0
+
+	for i := open; i < len(text); {
+		inCode := state.quote == "" && !state.blockComment && !state.lineComment
+		n := state.step(filePath, text, i)
+		stillCode := state.quote == "" && !state.blockComment && !state.lineComment
+
+		if inCode && stillCode && n == 1 {
+			switch text[i] {
+			case '{':
+				depth++
+			case '}':
+				depth--
+				if depth == 0 {
+					return i, true
+				}
+			}
+		}
+		i += n
+	}
+	return 0, false
+}
+
+// topLevelLines reports for each line whether it starts outside any string,
+// comment or bracket, with no indentation
+func topLevelLines(filePath, content string) []bool {
+	starts := openLines(filePath, content)
+	for i, line := range strings.SplitAfter(content, "\n") {
+		if indentation(line) > 0 {
+			starts[i] = false
+		}
+	}
+	return starts
+}
+
+// openLines reports for each line whether it starts outside any string, comment or bracket
+func openLines(filePath, content string) []bool {
+	var starts []bool
+	lineStart := true
+	depth := 0
+	var state lexState
+
+	for i := 0; i < len(content); {
+		if lineStart {
+			starts = append(starts, depth == 0 && state.quote == "" && !state.blockComment)
+			lineStart = false
+		}
+
+		c := content[i]
+		n := state.step(filePath, content, i)
+		if state.quote == "" && !state.blockComment && !state.lineComment && n == 1 {
+			switch c {
+			case '(', '[', '{':
+				depth++
+			case ')', ']', '}':
+				if depth > 0 {
+					depth--
+				}
+			}
+		}
+		if c == '\n' {
+			lineStart = true
+		}
+		i += n
+	}
+
+	// A trailing empty line after the last newline
+	if lineStart {
+		starts = append(starts, depth == 0)
+	}
+	return starts
+}
+
+// splitCode returns the code of the file with strings and comments left out
+func splitCode(filePath, content string) []string {
+	var code []string
+	var current strings.Builder
+	var state lexState
+
+	for i := 0; i < len(content); {
+		inCode := state.quote == "" && !state.blockComment && !state.lineComment
+		n := state.step(filePath, content, i)
+		stillCode := state.quote == "" && !state.blockComment && !state.lineComment
+
+		if inCode && stillCode {
+			current.WriteString(content[i : i+n])
+		} else if current.Len() > 0 {
+			code = append(code, current.String())
+			current.Reset()
+		}
+		i += n
+	}
+
+	if current.Len() > 0 {
+		code = append(code, current.String())
+	}
+	return code
+}
+
+// lexState tracks strings and comments while scanning Python or JS
+type lexState struct {
+	quote        string
+	blockComment bool
+	lineComment  bool
+}
+
+// step consumes the token at i and returns its length
+func (s *lexState) step(filePath, content string, i int) int {
+	python := path.Ext(filePath) == ".py"
+	rest := content[i:]
+
+	switch {
+	case s.lineComment:
+		if rest[0] == '\n' {
+			s.lineComment = false
+		}
+		return 1
+
+	case s.blockComment:
+		if strings.HasPrefix(rest, "*/") {
+			s.blockComment = false
+			return 2
+		}
+		return 1
+
+	case s.quote != "":
+		if rest[0] == '\\' && len(rest) > 1 {
+			return 2
+		}
+		if strings.HasPrefix(rest, s.quote) {
+			n := len(s.quote)
+			s.quote = ""
+			return n
+		}
+		// Single quoted strings end at the line in both languages, templates do not
+		if rest[0] == '\n' && len(s.quote) == 1 && s.quote != "`" {
+			s.quote = ""
+		}
+		return 1
+	}
+
+	switch {
+	case python && (strings.HasPrefix(rest, `"""`) || strings.HasPrefix(rest, `'''`)):
+		s.quote = rest[:3]
+		return 3
+	case rest[0] == '"' || rest[0] == '\'' || (!python && rest[0] == '`'):
+		s.quote = rest[:1]
+		return 1
+	case python && rest[0] == '#':
+		s.lineComment = true
+		return 1
+	case !python && strings.HasPrefix(rest, "//"):
+		s.lineComment = true
+		return 2
+	case !python && strings.HasPrefix(rest, "/*"):
+		s.blockComment = true
+		return 2
+	}
+	return 1
+}
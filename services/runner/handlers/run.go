@@ -0,0 +1,77 @@
+package handlers
+
+import (
+	"context"
+	"log"
+	"os"
+
+	pb "github.com/codesourcerer-bot/proto/generated"
+	"github.com/codesourcerer-bot/runner/resolvers"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func runTests(ctx context.Context, limits resolvers.Limits, cacheDir string, payload *pb.RunTestsRequest) (*pb.RunTestsResponse, error) {
+	if payload.GetTarballUrl() == "" || len(payload.GetTests()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "a tarball URL and at least one test are required")
+	}
+
+	if err := resolvers.CheckTarballURL(payload.GetTarballUrl()); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	paths := make([]string, 0, len(payload.GetTests()))
+	for _, test := range payload.GetTests() {
+		paths = append(paths, test.GetTestfilepath())
+	}
+
+	command, err := resolvers.TestCommand(payload.GetFramework(), paths)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	dir, err := resolvers.PrepareWorkspace(ctx, payload.GetTarballUrl(), payload.GetTests(), limits)
+	if err != nil {
+		log.Printf("Unable to prepare workspace: %v", err)
+		return nil, status.Errorf(codes.FailedPrecondition, "unable to prepare workspace: %v", err)
+	}
+	defer func() {
+		if err := os.RemoveAll(dir); err != nil {
+			log.Printf("Unable to remove workspace %s: %v", dir, err)
+		}
+	}()
+
+	install, err := resolvers.InstallDependencies(ctx, dir, cacheDir, payload.GetFramework(), limits)
+	if err != nil {
+		log.Printf("Unable to install %s dependencies: %v", payload.GetFramework(), err)
+		return nil, status.Errorf(codes.Internal, "unable to install dependencies: %v", err)
+	}
+	if install != nil {
+		log.Printf("Dependencies of %s tests could not be installed: exit code %d, timed out %v", payload.GetFramework(), install.ExitCode, install.TimedOut)
+		return &pb.RunTestsResponse{
+			ExitCode:           int32(install.ExitCode),
+			TimedOut:           install.TimedOut,
+			Logs:               append([]string{"Installing the dependencies failed"}, install.Logs...),
+			EnvironmentFailure: true,
+		}, nil
+	}
+
+	result, err := resolvers.RunSandboxed(ctx, dir, cacheDir, command, limits)
+	if err != nil {
+		log.Printf("Unable to run %s: %v", payload.GetFramework(), err)
+		return nil, status.Errorf(codes.Internal, "unable to run tests: %v", err)
+	}
+
+	environmentFailure := resolvers.IsEnvironmentFailure(payload.GetFramework(), dir, paths, result)
+
+	log.Printf("Ran %d %s test files: exit code %d, timed out %v, environment failure %v", len(paths), payload.GetFramework(), result.ExitCode, result.TimedOut, environmentFailure)
+
+	return &pb.RunTestsResponse{
+		Passed:             result.ExitCode == 0 && !result.TimedOut,
+		ExitCode:           int32(result.ExitCode),
+		TimedOut:           result.TimedOut,
+		Logs:               result.Logs,
+		EnvironmentFailure: environmentFailure,
+	}, nil
+}
@@ -0,0 +1,26 @@
+package handlers
+
+import (
+	"context"
+
+	pb "github.com/codesourcerer-bot/proto/generated"
+	"github.com/codesourcerer-bot/runner/resolvers"
+
+	"google.golang.org/grpc"
+)
+
+type server struct {
+	limits   resolvers.Limits
+	cacheDir string
+	pb.UnimplementedRunnerServiceServer
+}
+
+func GetGrpcServer(limits resolvers.Limits, cacheDir string) *grpc.Server {
+	grpcServer := grpc.NewServer()
+	pb.RegisterRunnerServiceServer(grpcServer, &server{limits: limits, cacheDir: cacheDir})
+	return grpcServer
+}
+
+func (s *server) RunTests(ctx context.Context, payload *pb.RunTestsRequest) (*pb.RunTestsResponse, error) {
+	return runTests(ctx, s.limits, s.cacheDir, payload)
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"log"
+
+	"github.com/codesourcerer-bot/runner/handlers"
+	"github.com/codesourcerer-bot/runner/resolvers"
+	"github.com/codesourcerer-bot/runner/utils"
+)
+
+func main() {
+	utils.LoadEnv()
+
+	if err := resolvers.CheckSandboxTools(); err != nil {
+		log.Fatalf("Unable to sandbox test runs: %v", err)
+	}
+
+	limits, err := resolvers.LoadLimits()
+	if err != nil {
+		log.Fatalf("Invalid runner limits: %v", err)
+	}
+
+	cacheDir, err := resolvers.LoadCacheDir()
+	if err != nil {
+		log.Fatalf("Invalid runner cache: %v", err)
+	}
+
+	lis, port := utils.GetListener()
+
+	grpcServer := handlers.GetGrpcServer(limits, cacheDir)
+
+	log.Println("Runner gRPC Server started at PORT ", port)
+
+	if err := grpcServer.Serve(lis); err != nil {
+		log.Fatalf("Unable to gRPC Server: %v", err)
+	}
+
+}
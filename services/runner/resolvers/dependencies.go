@@ -0,0 +1,97 @@
+package resolvers
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+)
+
+// pythonDepsDir holds the Python requirements installed for a run, it is on the PYTHONPATH
+const pythonDepsDir = ".runner-deps"
+
+// pythonRequirements are the requirement files installed before Python tests
+var pythonRequirements = []string{"requirements.txt", "requirements-dev.txt", "requirements-test.txt"}
+
+// InstallCommands lists the commands fetching the dependencies declared by the
+// manifests at the root of dir. No code of the packages runs: npm skips the
+// install scripts and pip only takes wheels, as building an sdist runs its setup.py.
+func InstallCommands(framework, dir string) [][]string {
+	exists := func(name string) bool {
+		_, err := os.Stat(filepath.Join(dir, name))
+		return err == nil
+	}
+
+	switch framework {
+	case "go-test":
+		// Without a go.sum the module has no dependencies to download
+		if exists("go.mod") && exists("go.sum") {
+			return [][]string{{"go", "mod", "download"}}
+		}
+
+	case "jest", "vitest", "mocha":
+		if !exists("package.json") {
+			return nil
+		}
+		if exists("package-lock.json") || exists("npm-shrinkwrap.json") {
+			return [][]string{{"npm", "ci", "--ignore-scripts", "--no-audit", "--no-fund"}}
+		}
+		return [][]string{{"npm", "install", "--ignore-scripts", "--no-audit", "--no-fund"}}
+
+	case "pytest", "unittest":
+		command := []string{"python3", "-m", "pip", "install", "--quiet", "--disable-pip-version-check", "--only-binary=:all:", "--target", pythonDepsDir}
+		found := false
+		for _, name := range pythonRequirements {
+			if exists(name) {
+				command = append(command, "-r", name)
+				found = true
+			}
+		}
+		if found {
+			return [][]string{command}
+		}
+	}
+
+	return nil
+}
+
+// InstallDependencies fetches the dependencies of the repository in dir before
+// the network is cut for the tests. Downloads go through the shared cache in
+// cacheDir. The result of the first failing command is returned, nil when
+// there was nothing to install or everything was installed.
+func InstallDependencies(ctx context.Context, dir, cacheDir, framework string, limits Limits) (*Result, error) {
+	env := append(baseEnv(dir, cacheDir),
+		"NPM_CONFIG_CACHE="+path.Join(cacheDir, "npm"),
+		"PIP_CACHE_DIR="+path.Join(cacheDir, "pip"),
+		"PIP_BREAK_SYSTEM_PACKAGES=1",
+	)
+
+	// Installs keep the network and fill the cache, but still run as an
+	// unprivileged user that cannot see the other workspaces
+	for _, command := range InstallCommands(framework, dir) {
+		result, err := runLimited(ctx, dir, isolation(dir, cacheDir, false), command, env, limits)
+		if err != nil {
+			return nil, fmt.Errorf("unable to run %s: %v", command[0], err)
+		}
+		if result.ExitCode != 0 || result.TimedOut {
+			return result, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// LoadCacheDir reads RUNNER_CACHE_DIR, where downloaded dependencies are kept
+// between runs, and creates it
+func LoadCacheDir() (string, error) {
+	dir := os.Getenv("RUNNER_CACHE_DIR")
+	if dir == "" {
+		dir = filepath.Join(os.TempDir(), "runner-cache")
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", fmt.Errorf("unable to create cache directory %s: %v", dir, err)
+	}
+	return dir, nil
+}
@@ -0,0 +1,121 @@
+package resolvers
+
+import (
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+var (
+	pythonMissingModule = regexp.MustCompile(`No module named '([^'.]+)`)
+	jsMissingModule     = regexp.MustCompile(`Cannot find (?:module|package) '([^']+)'`)
+)
+
+// goEnvironmentErrors are printed by go when the module graph cannot be loaded
+var goEnvironmentErrors = []string{
+	"[setup failed]",
+	"no required module provides package",
+	"missing go.sum entry",
+	"updates to go.mod needed",
+	"cannot find module providing package",
+}
+
+// jsEnvironmentErrors are printed by npx when the test runner is not installed
+var jsEnvironmentErrors = []string{
+	"could not determine executable to run",
+	"npm ERR! code ENOTCACHED",
+	"npm error code ENOTCACHED",
+}
+
+// IsEnvironmentFailure reports whether a failed run did not get to test anything
+// because of the repository or its dependencies, such as a missing package or a
+// build broken outside the generated tests. Regenerating the tests cannot fix
+// these failures.
+func IsEnvironmentFailure(framework, dir string, tests []string, result *Result) bool {
+	if result.ExitCode == 0 || result.TimedOut {
+		return false
+	}
+
+	switch framework {
+	case "go-test":
+		return isGoEnvironmentFailure(tests, result.Logs)
+	case "pytest", "unittest":
+		// pytest exits with 3 on internal errors and 4 on usage errors
+		if result.ExitCode == 3 || result.ExitCode == 4 {
+			return true
+		}
+		return missingExternalModule(pythonMissingModule, dir, result.Logs)
+	case "jest", "vitest", "mocha":
+		if containsAny(result.Logs, jsEnvironmentErrors) {
+			return true
+		}
+		return missingExternalModule(jsMissingModule, dir, result.Logs)
+	}
+	return false
+}
+
+// isGoEnvironmentFailure is true when the module graph could not be loaded, or
+// when the build failed without a single error in the generated tests
+func isGoEnvironmentFailure(tests []string, logs []string) bool {
+	if containsAny(logs, goEnvironmentErrors) {
+		return true
+	}
+	if !containsAny(logs, []string{"[build failed]"}) {
+		return false
+	}
+
+	for _, line := range logs {
+		for _, test := range tests {
+			if strings.Contains(line, path.Base(test)+":") {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// missingExternalModule is true when the logs report a missing module that is
+// not part of the repository, so it has to be a dependency that is not installed
+func missingExternalModule(pattern *regexp.Regexp, dir string, logs []string) bool {
+	for _, line := range logs {
+		match := pattern.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+
+		module := match[1]
+		if strings.HasPrefix(module, ".") || strings.HasPrefix(module, "/") {
+			continue
+		}
+		if !inRepository(dir, strings.SplitN(module, "/", 2)[0]) {
+			return true
+		}
+	}
+	return false
+}
+
+// inRepository reports whether a top-level module name is a file or directory
+// of the repository, at the root or under src
+func inRepository(dir, name string) bool {
+	for _, root := range []string{dir, filepath.Join(dir, "src")} {
+		for _, candidate := range []string{name, name + ".py"} {
+			if _, err := os.Stat(filepath.Join(root, candidate)); err == nil {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func containsAny(logs []string, needles []string) bool {
+	for _, line := range logs {
+		for _, needle := range needles {
+			if strings.Contains(line, needle) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,49 @@
+package resolvers
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+)
+
+// Limits bound a single test run
+type Limits struct {
+	Timeout    time.Duration
+	MemoryMB   int
+	CPUSeconds int
+	ArchiveMB  int
+	OutputKB   int
+}
+
+// LoadLimits reads the limits from the environment, using the defaults for unset variables
+func LoadLimits() (Limits, error) {
+	limits := Limits{Timeout: 5 * time.Minute, MemoryMB: 2048, CPUSeconds: 300, ArchiveMB: 200, OutputKB: 512}
+
+	if value := os.Getenv("RUNNER_TIMEOUT"); value != "" {
+		timeout, err := time.ParseDuration(value)
+		if err != nil || timeout <= 0 {
+			return limits, fmt.Errorf("RUNNER_TIMEOUT must be a positive duration, got %q", value)
+		}
+		limits.Timeout = timeout
+	}
+
+	for name, field := range map[string]*int{
+		"RUNNER_MEMORY_MB":      &limits.MemoryMB,
+		"RUNNER_CPU_SECONDS":    &limits.CPUSeconds,
+		"RUNNER_MAX_ARCHIVE_MB": &limits.ArchiveMB,
+		"RUNNER_MAX_OUTPUT_KB":  &limits.OutputKB,
+	} {
+		value := os.Getenv(name)
+		if value == "" {
+			continue
+		}
+		n, err := strconv.Atoi(value)
+		if err != nil || n <= 0 {
+			return limits, fmt.Errorf("%s must be a positive integer, got %q", name, value)
+		}
+		*field = n
+	}
+
+	return limits, nil
+}
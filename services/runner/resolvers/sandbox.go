@@ -0,0 +1,221 @@
+package resolvers
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// tempDir is created in the workspace for the temporary files of a run
+const tempDir = ".runner-tmp"
+
+// sandboxTools wrap every run: unshare drops the network, mount hides the other
+// workspaces, prlimit caps memory and CPU
+var sandboxTools = []string{"unshare", "mount", "prlimit"}
+
+// stagingDir holds the workspace and the cache while the directory of the
+// workspaces is covered, it must exist outside of it
+const stagingDir = "/mnt"
+
+// isolateScript runs as root of a private mount namespace. It covers the
+// directory of the workspaces with an empty tmpfs, so that a run only sees its
+// own workspace, and mounts the dependency cache read-only when asked to.
+// Every run shares the uid of the service, so file permissions alone cannot
+// keep runs apart.
+const isolateScript = `set -e
+workspace=$1 workspaces=$2 cache=$3 cache_mode=$4
+shift 4
+mount -t tmpfs tmpfs ` + stagingDir + `
+mkdir ` + stagingDir + `/workspace ` + stagingDir + `/cache
+mount --bind "$workspace" ` + stagingDir + `/workspace
+mount --bind "$cache" ` + stagingDir + `/cache
+if [ "$cache_mode" = ro ]; then
+	mount -o remount,bind,ro ` + stagingDir + `/cache
+fi
+mount -t tmpfs tmpfs "$workspaces"
+mkdir -p "$workspace" "$cache"
+mount --bind ` + stagingDir + `/workspace "$workspace"
+mount --bind ` + stagingDir + `/cache "$cache"
+umount -l ` + stagingDir + `
+exec "$@"
+`
+
+// Result is the outcome of a sandboxed run
+type Result struct {
+	ExitCode int
+	TimedOut bool
+	Logs     []string
+}
+
+// CheckSandboxTools fails when the tools used to isolate runs are missing
+func CheckSandboxTools() error {
+	for _, tool := range sandboxTools {
+		if _, err := exec.LookPath(tool); err != nil {
+			return fmt.Errorf("%s not found: %v", tool, err)
+		}
+	}
+	return nil
+}
+
+// TestCommand builds the command that runs the test files with the framework
+func TestCommand(framework string, files []string) ([]string, error) {
+	switch framework {
+	case "pytest", "unittest":
+		return append([]string{"python3", "-m", "pytest", "-q", "-p", "no:cacheprovider"}, files...), nil
+	case "go-test":
+		return append([]string{"go", "test"}, goPackages(files)...), nil
+	case "jest":
+		return append([]string{"npx", "--no-install", "jest", "--ci"}, files...), nil
+	case "vitest":
+		return append([]string{"npx", "--no-install", "vitest", "run"}, files...), nil
+	case "mocha":
+		return append([]string{"npx", "--no-install", "mocha"}, files...), nil
+	}
+	return nil, fmt.Errorf("unsupported testing framework %q", framework)
+}
+
+// goPackages lists the packages of the test files, go test runs packages rather than files
+func goPackages(files []string) []string {
+	seen := make(map[string]bool)
+	var packages []string
+	for _, file := range files {
+		pkg := "./" + path.Dir(file)
+		if !seen[pkg] {
+			seen[pkg] = true
+			packages = append(packages, pkg)
+		}
+	}
+	sort.Strings(packages)
+	return packages
+}
+
+// RunSandboxed runs the command in dir without network access and with the
+// cache read-only, under the memory and CPU limits, and kills the whole process
+// group when the timeout expires
+func RunSandboxed(ctx context.Context, dir, cacheDir string, command []string, limits Limits) (*Result, error) {
+	return runLimited(ctx, dir, isolation(dir, cacheDir, true), command, sandboxEnv(dir, cacheDir), limits)
+}
+
+// isolation is the prefix running a command as an unprivileged root in its own
+// mount and PID namespaces, where the other workspaces are hidden. Offline runs
+// also lose the network and may only read the cache.
+func isolation(dir, cacheDir string, offline bool) []string {
+	argv := []string{"unshare", "--map-root-user", "--mount", "--pid", "--fork", "--mount-proc"}
+	cacheMode := "rw"
+	if offline {
+		argv = append(argv, "--net")
+		cacheMode = "ro"
+	}
+	return append(argv, "--", "sh", "-c", isolateScript, "isolate", dir, filepath.Dir(dir), cacheDir, cacheMode)
+}
+
+// runLimited runs the command in dir behind the isolation prefix, under prlimit
+// and with the timeout of limits
+func runLimited(ctx context.Context, dir string, isolation, command, env []string, limits Limits) (*Result, error) {
+	if err := os.MkdirAll(path.Join(dir, tempDir), 0o755); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, limits.Timeout)
+	defer cancel()
+
+	argv := append([]string{}, isolation...)
+	argv = append(argv,
+		"prlimit",
+		"--as="+strconv.Itoa(limits.MemoryMB<<20),
+		"--cpu="+strconv.Itoa(limits.CPUSeconds),
+		"--",
+	)
+	argv = append(argv, command...)
+
+	cmd := exec.CommandContext(ctx, argv[0], argv[1:]...)
+	cmd.Dir = dir
+	cmd.Env = env
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	cmd.Cancel = func() error {
+		return syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+	}
+	cmd.WaitDelay = 10 * time.Second
+
+	output := &cappedBuffer{max: limits.OutputKB << 10}
+	cmd.Stdout = output
+	cmd.Stderr = output
+
+	err := cmd.Run()
+	result := &Result{TimedOut: errors.Is(ctx.Err(), context.DeadlineExceeded), Logs: output.lines()}
+
+	var exitErr *exec.ExitError
+	switch {
+	case err == nil:
+	case errors.As(err, &exitErr):
+		result.ExitCode = exitErr.ExitCode()
+	case result.TimedOut:
+		result.ExitCode = -1
+	default:
+		return nil, err
+	}
+
+	if result.TimedOut {
+		result.Logs = append(result.Logs, fmt.Sprintf("Run killed after %s", limits.Timeout))
+	}
+	return result, nil
+}
+
+// baseEnv is shared by every run. Nothing from the service leaks in.
+func baseEnv(dir, cacheDir string) []string {
+	return []string{
+		"PATH=/usr/local/go/bin:/usr/local/sbin:/usr/local/bin:/usr/sbin:/usr/bin:/sbin:/bin",
+		"HOME=" + dir,
+		// go ignores a go.mod at the root of TMPDIR, so it cannot be the workspace
+		"TMPDIR=" + path.Join(dir, tempDir),
+		"CI=true",
+		"GOFLAGS=-mod=mod",
+		"GOCACHE=" + path.Join(dir, ".cache", "go-build"),
+		"GOMODCACHE=" + path.Join(cacheDir, "go-mod"),
+		"PYTHONDONTWRITEBYTECODE=1",
+		"PYTHONPATH=" + path.Join(dir, pythonDepsDir),
+	}
+}
+
+// sandboxEnv is the environment of a test run. Tools that would reach the
+// network are told to stay offline, the dependencies were installed before.
+func sandboxEnv(dir, cacheDir string) []string {
+	return append(baseEnv(dir, cacheDir), "GOPROXY=off", "NPM_CONFIG_OFFLINE=true")
+}
+
+// cappedBuffer keeps the first max bytes written and notes that the rest was cut
+type cappedBuffer struct {
+	buf       bytes.Buffer
+	max       int
+	truncated bool
+}
+
+func (b *cappedBuffer) Write(p []byte) (int, error) {
+	if room := b.max - b.buf.Len(); room < len(p) {
+		b.truncated = true
+		b.buf.Write(p[:max(room, 0)])
+		return len(p), nil
+	}
+	return b.buf.Write(p)
+}
+
+func (b *cappedBuffer) lines() []string {
+	lines := strings.Split(strings.TrimRight(b.buf.String(), "\n"), "\n")
+	if len(lines) == 1 && lines[0] == "" {
+		lines = nil
+	}
+	if b.truncated {
+		lines = append(lines, fmt.Sprintf("Output truncated after %d KB", b.max>>10))
+	}
+	return lines
+}
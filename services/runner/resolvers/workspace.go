@@ -0,0 +1,182 @@
+package resolvers
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	pb "github.com/codesourcerer-bot/proto/generated"
+)
+
+// PrepareWorkspace downloads the repository tarball into a fresh directory and
+// writes the generated tests over it. The caller removes the directory.
+func PrepareWorkspace(ctx context.Context, tarballURL string, tests []*pb.TestFilePayload, limits Limits) (string, error) {
+	dir, err := os.MkdirTemp("", "runner-")
+	if err != nil {
+		log.Printf("Unable to create workspace: %v", err)
+		return "", fmt.Errorf("unable to create workspace")
+	}
+
+	if err := downloadTarball(ctx, tarballURL, dir, int64(limits.ArchiveMB)<<20); err != nil {
+		os.RemoveAll(dir)
+		return "", err
+	}
+
+	for _, test := range tests {
+		target, err := workspacePath(dir, test.GetTestfilepath())
+		if err != nil {
+			os.RemoveAll(dir)
+			return "", err
+		}
+		if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+			os.RemoveAll(dir)
+			return "", fmt.Errorf("unable to create %s: %v", path.Dir(test.GetTestfilepath()), err)
+		}
+		if err := os.WriteFile(target, []byte(test.GetCode()), 0o644); err != nil {
+			os.RemoveAll(dir)
+			return "", fmt.Errorf("unable to write %s: %v", test.GetTestfilepath(), err)
+		}
+	}
+
+	return dir, nil
+}
+
+// workspacePath resolves a repository path inside dir, rejecting paths that
+// would escape it
+func workspacePath(dir, name string) (string, error) {
+	clean := path.Clean(strings.ReplaceAll(name, "\\", "/"))
+	if clean == "." || path.IsAbs(clean) || clean == ".." || strings.HasPrefix(clean, "../") {
+		return "", fmt.Errorf("unsafe path %q", name)
+	}
+	return filepath.Join(dir, filepath.FromSlash(clean)), nil
+}
+
+// tarballHost serves the archive links handed out by the GitHub API. Tarballs
+// are only downloaded from it, so that callers cannot point the runner elsewhere.
+const tarballHost = "codeload.github.com"
+
+// tarballClient refuses redirects that leave the tarball host
+var tarballClient = &http.Client{
+	CheckRedirect: func(req *http.Request, via []*http.Request) error {
+		if len(via) >= 10 {
+			return errors.New("stopped after 10 redirects")
+		}
+		return CheckTarballURL(req.URL.String())
+	},
+}
+
+// CheckTarballURL fails unless the URL is an https link to the tarball host
+func CheckTarballURL(tarballURL string) error {
+	u, err := url.Parse(tarballURL)
+	if err != nil || u.Scheme != "https" || u.Host != tarballHost || u.User != nil {
+		return fmt.Errorf("tarball URL must be an https link to %s", tarballHost)
+	}
+	return nil
+}
+
+// downloadTarball extracts a GitHub tarball into dir, dropping the top-level
+// directory GitHub wraps the repository in. Links and special files are skipped.
+func downloadTarball(ctx context.Context, tarballURL, dir string, maxBytes int64) error {
+	if err := CheckTarballURL(tarballURL); err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, tarballURL, nil)
+	if err != nil {
+		return fmt.Errorf("invalid tarball URL: %v", err)
+	}
+
+	resp, err := tarballClient.Do(req)
+	if err != nil {
+		log.Printf("Unable to download tarball: %v", err)
+		return fmt.Errorf("unable to download tarball")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unable to download tarball: %s", resp.Status)
+	}
+
+	// One extra byte tells an archive of exactly maxBytes from a larger one
+	body := &io.LimitedReader{R: resp.Body, N: maxBytes + 1}
+
+	gz, err := gzip.NewReader(body)
+	if err != nil {
+		return fmt.Errorf("tarball is not gzipped: %v", err)
+	}
+	defer gz.Close()
+
+	var written int64
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			if body.N <= 0 {
+				return fmt.Errorf("tarball is larger than %d MB", maxBytes>>20)
+			}
+			return fmt.Errorf("unable to read tarball: %v", err)
+		}
+
+		_, name, found := strings.Cut(header.Name, "/")
+		if !found || name == "" {
+			continue
+		}
+
+		target, err := workspacePath(dir, name)
+		if err != nil {
+			return err
+		}
+
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, 0o755); err != nil {
+				return fmt.Errorf("unable to create %s: %v", name, err)
+			}
+
+		case tar.TypeReg:
+			// Extracted size is capped too, so a small archive cannot fill the disk
+			written += header.Size
+			if written > maxBytes*4 {
+				return fmt.Errorf("tarball expands to more than %d MB", maxBytes*4>>20)
+			}
+			if err := extractFile(tr, target, header.FileInfo().Mode().Perm()); err != nil {
+				return fmt.Errorf("unable to extract %s: %v", name, err)
+			}
+		}
+	}
+
+	if body.N <= 0 {
+		return fmt.Errorf("tarball is larger than %d MB", maxBytes>>20)
+	}
+	return nil
+}
+
+func extractFile(r io.Reader, target string, perm os.FileMode) error {
+	if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(target, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, perm|0o600)
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(file, r); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}